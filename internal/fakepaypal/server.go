@@ -0,0 +1,679 @@
+// Package fakepaypal is an in-memory stand-in for the parts of the PayPal REST API
+// used by the provider, so resource lifecycles can be tested without PayPal credentials
+package fakepaypal
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Credentials accepted by the fake OAuth token endpoint
+const (
+	ClientID     = "fake-client-id"
+	ClientSecret = "fake-client-secret"
+	AccessToken  = "fake-access-token"
+)
+
+// webhookEventTypes The event types the fake lists as available for webhooks
+var webhookEventTypes = []object{
+	{"name": "BILLING.SUBSCRIPTION.CREATED", "description": "A billing subscription is created.", "status": "ENABLED"},
+	{"name": "BILLING.SUBSCRIPTION.CANCELLED", "description": "A billing subscription is cancelled.", "status": "ENABLED"},
+	{"name": "PAYMENT.SALE.COMPLETED", "description": "A payment sale is completed.", "status": "ENABLED"},
+	{"name": "PAYMENT.SALE.REFUNDED", "description": "A merchant refunds a payment sale.", "status": "ENABLED"},
+	{"name": "CUSTOMER.DISPUTE.CREATED", "description": "A dispute is created.", "status": "ENABLED"},
+	{"name": "PAYMENT.CAPTURE.DECLINED", "description": "A payment capture is declined.", "status": "ENABLED"},
+}
+
+// object A JSON object stored by the fake API
+type object = map[string]interface{}
+
+// Request A request received by the fake API
+type Request struct {
+	Method string
+	Path   string
+	Header http.Header
+}
+
+// failure A queued error response
+type failure struct {
+	status     int
+	retryAfter string
+
+	// afterHandling The request is handled before the error is sent, as when PayPal times out after doing the work
+	afterHandling bool
+}
+
+// Server An in-memory PayPal REST API served over HTTP
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	sequence int
+	requests []Request
+	failures []failure
+	webhooks map[string]object
+	products map[string]object
+	plans    map[string]object
+	events   map[string]object
+
+	// requestIDs The ID of the object created for each PayPal-Request-Id, by endpoint
+	requestIDs map[string]string
+}
+
+// NewServer Start a new fake PayPal API. Call Close when done with it
+func NewServer() *Server {
+	s := &Server{
+		webhooks: map[string]object{},
+		products: map[string]object{},
+		plans:    map[string]object{},
+		events:   map[string]object{},
+
+		requestIDs: map[string]string{},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.ServeHTTP))
+	return s
+}
+
+// ServeHTTP Route a request to the matching fake endpoint
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	next, failing := s.nextFailure(r)
+	if !failing {
+		s.serve(w, r)
+		return
+	}
+	if next.afterHandling {
+		s.serve(httptest.NewRecorder(), r)
+	}
+	if next.retryAfter != "" {
+		w.Header().Set("Retry-After", next.retryAfter)
+	}
+	if next.status == http.StatusTooManyRequests {
+		writeError(w, next.status, "RATE_LIMIT_REACHED", "Too many requests. Blocked due to rate limiting.")
+	} else {
+		writeError(w, next.status, "INTERNAL_SERVICE_ERROR", "An internal service error has occurred.")
+	}
+}
+
+// serve Handle a request that is not failing
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+
+	if r.URL.Path == "/v1/oauth2/token" {
+		s.token(w, r)
+		return
+	}
+
+	if r.Header.Get("Authorization") != "Bearer "+AccessToken {
+		writeError(w, http.StatusUnauthorized, "AUTHENTICATION_FAILURE", "Authentication failed due to invalid authentication credentials or a missing Authorization header.")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	switch {
+	case len(parts) == 3 && parts[0] == "v1" && parts[1] == "notifications" && parts[2] == "webhooks-event-types" && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, object{"event_types": webhookEventTypes})
+	case len(parts) == 3 && parts[0] == "v1" && parts[1] == "notifications" && parts[2] == "verify-webhook-signature" && r.Method == http.MethodPost:
+		s.verifyWebhookSignature(w, r)
+	case len(parts) == 3 && parts[0] == "v1" && parts[1] == "notifications" && parts[2] == "simulate-event" && r.Method == http.MethodPost:
+		s.simulateEvent(w, r)
+	case len(parts) >= 3 && parts[0] == "v1" && parts[1] == "notifications" && parts[2] == "webhooks":
+		s.collection(w, r, parts[3:], "WH", s.webhooks, s.listWebhooks)
+	case len(parts) >= 3 && parts[0] == "v1" && parts[1] == "catalogs" && parts[2] == "products":
+		s.collection(w, r, parts[3:], "PROD", s.products, s.listProducts)
+	case len(parts) >= 3 && parts[0] == "v1" && parts[1] == "billing" && parts[2] == "plans":
+		if len(parts) == 5 && r.Method == http.MethodPost {
+			s.planAction(w, r, parts[3], parts[4])
+			return
+		}
+		s.collection(w, r, parts[3:], "P", s.plans, s.listPlans)
+	default:
+		writeError(w, http.StatusNotFound, "RESOURCE_NOT_FOUND", "The requested resource was not found.")
+	}
+}
+
+// FailNext Respond to the next count requests with the given status, and a Retry-After
+// header if retryAfter is not empty
+func (s *Server) FailNext(count int, status int, retryAfter string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := 0; i < count; i++ {
+		s.failures = append(s.failures, failure{status: status, retryAfter: retryAfter})
+	}
+}
+
+// FailNextAfterHandling Handle the next count requests, but respond to them with the given status,
+// as when PayPal times out after creating an object
+func (s *Server) FailNextAfterHandling(count int, status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := 0; i < count; i++ {
+		s.failures = append(s.failures, failure{status: status, afterHandling: true})
+	}
+}
+
+// Requests Get every request received so far, including failed ones
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request{}, s.requests...)
+}
+
+// nextFailure Record the request and take the next queued failure if there is one
+func (s *Server) nextFailure(r *http.Request) (failure, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, Request{Method: r.Method, Path: r.URL.Path, Header: r.Header.Clone()})
+	if len(s.failures) == 0 {
+		return failure{}, false
+	}
+
+	next := s.failures[0]
+	s.failures = s.failures[1:]
+	return next, true
+}
+
+// Webhook Get a copy of a stored webhook, or nil if it does not exist
+func (s *Server) Webhook(id string) map[string]interface{} {
+	return s.get(s.webhooks, id)
+}
+
+// Product Get a copy of a stored product, or nil if it does not exist
+func (s *Server) Product(id string) map[string]interface{} {
+	return s.get(s.products, id)
+}
+
+// Plan Get a copy of a stored subscription plan, or nil if it does not exist
+func (s *Server) Plan(id string) map[string]interface{} {
+	return s.get(s.plans, id)
+}
+
+// Event Get a copy of a simulated webhook event, or nil if none was sent with that ID
+func (s *Server) Event(id string) map[string]interface{} {
+	return s.get(s.events, id)
+}
+
+// DeleteProduct Remove a product behind the provider's back, as if done in the dashboard
+func (s *Server) DeleteProduct(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.products, id)
+}
+
+// DeletePlan Remove a subscription plan behind the provider's back, as if done in the dashboard
+func (s *Server) DeletePlan(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.plans, id)
+}
+
+// DeleteWebhook Remove a webhook behind the provider's back, as if done in the dashboard
+func (s *Server) DeleteWebhook(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.webhooks, id)
+}
+
+func (s *Server) get(store map[string]object, id string) map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	obj, ok := store[id]
+	if !ok {
+		return nil
+	}
+	return copyObject(obj)
+}
+
+// token The OAuth client credentials exchange
+func (s *Server) token(w http.ResponseWriter, r *http.Request) {
+	clientID, clientSecret, ok := r.BasicAuth()
+	if r.Method != http.MethodPost || !ok || clientID != ClientID || clientSecret != ClientSecret {
+		writeJSON(w, http.StatusUnauthorized, object{
+			"error":             "invalid_client",
+			"error_description": "Client Authentication failed",
+		})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, object{
+		"scope":        "https://uri.paypal.com/services/subscriptions",
+		"access_token": AccessToken,
+		"token_type":   "Bearer",
+		"app_id":       "APP-FAKE",
+		"expires_in":   32400,
+		"nonce":        "fake-nonce",
+	})
+}
+
+// collection Generic create/list/get/patch/delete handling for a stored object type
+func (s *Server) collection(w http.ResponseWriter, r *http.Request, rest []string, prefix string, store map[string]object, list func(http.ResponseWriter, *http.Request)) {
+	if len(rest) == 0 {
+		switch r.Method {
+		case http.MethodPost:
+			s.create(w, r, prefix, store)
+		case http.MethodGet:
+			list(w, r)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_SUPPORTED", "The server does not implement the requested HTTP method.")
+		}
+		return
+	}
+
+	if len(rest) != 1 {
+		writeError(w, http.StatusNotFound, "RESOURCE_NOT_FOUND", "The requested resource was not found.")
+		return
+	}
+
+	obj, ok := store[rest[0]]
+	if !ok {
+		writeNotFound(w, rest[0])
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, obj)
+	case http.MethodPatch:
+		var ops []patchOperation
+		if err := json.NewDecoder(r.Body).Decode(&ops); err != nil {
+			writeError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+			return
+		}
+		if err := applyPatch(obj, ops); err != nil {
+			writeError(w, http.StatusUnprocessableEntity, "UNPROCESSABLE_ENTITY", err.Error())
+			return
+		}
+		obj["update_time"] = now()
+		if prefix == "WH" {
+			writeJSON(w, http.StatusOK, obj)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodDelete:
+		delete(store, rest[0])
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_SUPPORTED", "The server does not implement the requested HTTP method.")
+	}
+}
+
+// create Store a new object from the request body and return it with its assigned ID. A repeated
+// PayPal-Request-Id returns the object created the first time instead, as PayPal does
+func (s *Server) create(w http.ResponseWriter, r *http.Request, prefix string, store map[string]object) {
+	requestID := r.Header.Get("PayPal-Request-Id")
+	if requestID != "" {
+		if existing, ok := store[s.requestIDs[prefix+"/"+requestID]]; ok {
+			writeJSON(w, http.StatusOK, existing)
+			return
+		}
+	}
+
+	obj := object{}
+	if err := json.NewDecoder(r.Body).Decode(&obj); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	s.sequence++
+	id := fmt.Sprintf("%s-FAKE%08d", prefix, s.sequence)
+	obj["id"] = id
+	obj["create_time"] = now()
+	obj["update_time"] = obj["create_time"]
+
+	if prefix == "P" {
+		if status, _ := obj["status"].(string); status == "" {
+			obj["status"] = "ACTIVE"
+		}
+		preferences, _ := obj["payment_preferences"].(object)
+		if preferences == nil {
+			preferences = object{}
+			obj["payment_preferences"] = preferences
+		}
+		for key, value := range map[string]interface{}{
+			"auto_bill_outstanding":     true,
+			"setup_fee_failure_action":  "CANCEL",
+			"payment_failure_threshold": 0.0,
+		} {
+			if _, ok := preferences[key]; !ok {
+				preferences[key] = value
+			}
+		}
+		cycles, _ := obj["billing_cycles"].([]interface{})
+		for _, cycle := range cycles {
+			if pricing, ok := cycle.(object)["pricing_scheme"].(object); ok {
+				pricing["version"] = 1.0
+				pricing["create_time"] = obj["create_time"]
+				pricing["update_time"] = obj["create_time"]
+			}
+		}
+	}
+
+	store[id] = obj
+	if requestID != "" {
+		s.requestIDs[prefix+"/"+requestID] = id
+	}
+	writeJSON(w, http.StatusCreated, obj)
+}
+
+// planAction Subscription plan state transitions and pricing updates
+func (s *Server) planAction(w http.ResponseWriter, r *http.Request, id, action string) {
+	plan, ok := s.plans[id]
+	if !ok {
+		writeNotFound(w, id)
+		return
+	}
+
+	switch action {
+	case "activate":
+		if plan["status"] == "ACTIVE" {
+			writeError(w, http.StatusUnprocessableEntity, "UNPROCESSABLE_ENTITY", "Invalid plan status for activate action; plan status should be either created or inactive.")
+			return
+		}
+		plan["status"] = "ACTIVE"
+	case "deactivate":
+		if plan["status"] != "ACTIVE" {
+			writeError(w, http.StatusUnprocessableEntity, "UNPROCESSABLE_ENTITY", "Invalid plan status for deactivate action; plan status should be active.")
+			return
+		}
+		plan["status"] = "INACTIVE"
+	case "update-pricing-schemes":
+		var request struct {
+			PricingSchemes []struct {
+				BillingCycleSequence int    `json:"billing_cycle_sequence"`
+				PricingScheme        object `json:"pricing_scheme"`
+			} `json:"pricing_schemes"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			writeError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+			return
+		}
+		cycles, _ := plan["billing_cycles"].([]interface{})
+		for _, update := range request.PricingSchemes {
+			for _, cycle := range cycles {
+				cycleObj := cycle.(object)
+				if sequence, _ := cycleObj["sequence"].(float64); int(sequence) != update.BillingCycleSequence {
+					continue
+				}
+				version, createTime := 0.0, now()
+				if current, ok := cycleObj["pricing_scheme"].(object); ok {
+					version, _ = current["version"].(float64)
+					createTime, _ = current["create_time"].(string)
+				}
+				update.PricingScheme["version"] = version + 1
+				update.PricingScheme["create_time"] = createTime
+				update.PricingScheme["update_time"] = now()
+				cycleObj["pricing_scheme"] = update.PricingScheme
+			}
+		}
+	default:
+		writeError(w, http.StatusNotFound, "RESOURCE_NOT_FOUND", "The requested resource was not found.")
+		return
+	}
+
+	plan["update_time"] = now()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Sign The transmission signature the fake accepts for a webhook delivery
+func Sign(transmissionID, webhookID string) string {
+	return fmt.Sprintf("fake-signature-%s-%s", transmissionID, webhookID)
+}
+
+// verifyWebhookSignature A delivery verifies when it is for a known webhook and was signed with Sign
+func (s *Server) verifyWebhookSignature(w http.ResponseWriter, r *http.Request) {
+	var request struct {
+		AuthAlgo         string          `json:"auth_algo"`
+		CertURL          string          `json:"cert_url"`
+		TransmissionID   string          `json:"transmission_id"`
+		TransmissionSig  string          `json:"transmission_sig"`
+		TransmissionTime string          `json:"transmission_time"`
+		WebhookID        string          `json:"webhook_id"`
+		WebhookEvent     json.RawMessage `json:"webhook_event"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+	for _, value := range []string{request.AuthAlgo, request.CertURL, request.TransmissionID, request.TransmissionSig, request.TransmissionTime, request.WebhookID} {
+		if value == "" {
+			writeError(w, http.StatusBadRequest, "VALIDATION_ERROR", "Invalid data provided")
+			return
+		}
+	}
+
+	status := "FAILURE"
+	if _, ok := s.webhooks[request.WebhookID]; ok && request.TransmissionSig == Sign(request.TransmissionID, request.WebhookID) {
+		status = "SUCCESS"
+	}
+	writeJSON(w, http.StatusOK, object{"verification_status": status})
+}
+
+// simulateEvent Record a sample event for a known webhook or a URL, and return it as PayPal does
+func (s *Server) simulateEvent(w http.ResponseWriter, r *http.Request) {
+	var request struct {
+		WebhookID       string `json:"webhook_id"`
+		URL             string `json:"url"`
+		EventType       string `json:"event_type"`
+		ResourceVersion string `json:"resource_version"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+	if request.EventType == "" || (request.WebhookID == "") == (request.URL == "") {
+		writeError(w, http.StatusBadRequest, "VALIDATION_ERROR", "Invalid data provided")
+		return
+	}
+
+	url := request.URL
+	if request.WebhookID != "" {
+		webhook, ok := s.webhooks[request.WebhookID]
+		if !ok {
+			writeNotFound(w, request.WebhookID)
+			return
+		}
+		url, _ = webhook["url"].(string)
+	}
+	resourceVersion := request.ResourceVersion
+	if resourceVersion == "" {
+		resourceVersion = "1.0"
+	}
+
+	s.sequence++
+	event := object{
+		"id":               fmt.Sprintf("WH-SIM-FAKE%08d", s.sequence),
+		"create_time":      now(),
+		"resource_type":    strings.ToLower(strings.Split(request.EventType, ".")[0]),
+		"event_type":       request.EventType,
+		"event_version":    "1.0",
+		"resource_version": resourceVersion,
+		"summary":          fmt.Sprintf("Simulated %s event", request.EventType),
+		"resource":         object{},
+		"links":            []interface{}{},
+		"webhook_id":       request.WebhookID,
+		"url":              url,
+	}
+	s.events[event["id"].(string)] = event
+	writeJSON(w, http.StatusAccepted, event)
+}
+
+// listWebhooks Webhooks are returned in full and are not paginated
+func (s *Server) listWebhooks(w http.ResponseWriter, r *http.Request) {
+	webhooks := []interface{}{}
+	for _, id := range sortedIDs(s.webhooks) {
+		webhooks = append(webhooks, s.webhooks[id])
+	}
+	writeJSON(w, http.StatusOK, object{"webhooks": webhooks})
+}
+
+// listProducts Products are summarised in lists, as PayPal does
+func (s *Server) listProducts(w http.ResponseWriter, r *http.Request) {
+	summaries := []interface{}{}
+	for _, id := range sortedIDs(s.products) {
+		summaries = append(summaries, summarise(s.products[id], "id", "name", "description", "create_time"))
+	}
+	writePage(w, r, "products", summaries)
+}
+
+// listPlans Plans are summarised in lists and can be filtered by product and plan IDs, as PayPal does
+func (s *Server) listPlans(w http.ResponseWriter, r *http.Request) {
+	productID := r.URL.Query().Get("product_id")
+	planIDs := map[string]bool{}
+	for _, planID := range strings.Split(r.URL.Query().Get("plan_ids"), ",") {
+		if planID != "" {
+			planIDs[planID] = true
+		}
+	}
+
+	summaries := []interface{}{}
+	for _, id := range sortedIDs(s.plans) {
+		plan := s.plans[id]
+		if productID != "" && plan["product_id"] != productID {
+			continue
+		}
+		if len(planIDs) > 0 && !planIDs[id] {
+			continue
+		}
+		summaries = append(summaries, summarise(plan, "id", "product_id", "name", "status", "description", "create_time"))
+	}
+	writePage(w, r, "plans", summaries)
+}
+
+// writePage Write one page of a list response using PayPal's page/page_size/total_required parameters
+func writePage(w http.ResponseWriter, r *http.Request, key string, items []interface{}) {
+	query := r.URL.Query()
+	page, err := strconv.Atoi(query.Get("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+	pageSize, err := strconv.Atoi(query.Get("page_size"))
+	if err != nil || pageSize < 1 {
+		pageSize = 10
+	}
+
+	start := (page - 1) * pageSize
+	if start > len(items) {
+		start = len(items)
+	}
+	end := start + pageSize
+	if end > len(items) {
+		end = len(items)
+	}
+
+	response := object{key: items[start:end]}
+	if query.Get("total_required") == "true" {
+		response["total_items"] = len(items)
+		response["total_pages"] = (len(items) + pageSize - 1) / pageSize
+	}
+	links := []interface{}{object{"href": r.URL.String(), "rel": "self", "method": "GET"}}
+	if end < len(items) {
+		next := r.URL.Query()
+		next.Set("page", strconv.Itoa(page+1))
+		next.Set("page_size", strconv.Itoa(pageSize))
+		links = append(links, object{"href": r.URL.Path + "?" + next.Encode(), "rel": "next", "method": "GET"})
+	}
+	response["links"] = links
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+// patchOperation A JSON patch operation as sent by the SDK
+type patchOperation struct {
+	Operation string      `json:"op"`
+	Path      string      `json:"path"`
+	Value     interface{} `json:"value"`
+}
+
+// applyPatch Apply add/replace/remove operations to nested object fields
+func applyPatch(obj object, ops []patchOperation) error {
+	for _, op := range ops {
+		keys := strings.Split(strings.Trim(op.Path, "/"), "/")
+		target := obj
+		for _, key := range keys[:len(keys)-1] {
+			next, ok := target[key].(object)
+			if !ok {
+				next = object{}
+				target[key] = next
+			}
+			target = next
+		}
+
+		last := keys[len(keys)-1]
+		switch op.Operation {
+		case "add", "replace":
+			target[last] = op.Value
+		case "remove":
+			delete(target, last)
+		default:
+			return fmt.Errorf("unsupported patch operation %q", op.Operation)
+		}
+	}
+	return nil
+}
+
+// summarise Copy only the given fields of an object
+func summarise(obj object, fields ...string) object {
+	summary := object{}
+	for _, field := range fields {
+		if value, ok := obj[field]; ok {
+			summary[field] = value
+		}
+	}
+	return summary
+}
+
+// copyObject Deep copy an object via JSON so callers can't mutate server state
+func copyObject(obj object) object {
+	data, _ := json.Marshal(obj)
+	copied := object{}
+	json.Unmarshal(data, &copied)
+	return copied
+}
+
+func sortedIDs(store map[string]object) []string {
+	ids := []string{}
+	for id := range store {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func now() string {
+	return time.Now().UTC().Format(time.RFC3339)
+}
+
+func writeNotFound(w http.ResponseWriter, id string) {
+	writeJSON(w, http.StatusNotFound, object{
+		"name":     "RESOURCE_NOT_FOUND",
+		"message":  "The specified resource does not exist.",
+		"debug_id": "fake-debug-id",
+		"details": []interface{}{object{
+			"issue":       "INVALID_RESOURCE_ID",
+			"description": fmt.Sprintf("Specified resource ID %s does not exist. Please check the resource ID and try again.", id),
+		}},
+	})
+}
+
+func writeError(w http.ResponseWriter, status int, name, message string) {
+	writeJSON(w, status, object{
+		"name":     name,
+		"message":  message,
+		"debug_id": "fake-debug-id",
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,233 @@
+package fakepaypal
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	paypalSdk "github.com/plutov/paypal/v4"
+)
+
+func testClient(t *testing.T, server *Server) *paypalSdk.Client {
+	client, err := paypalSdk.NewClient(ClientID, ClientSecret, server.URL)
+	if err != nil {
+		t.Fatalf("Expected no error creating client. Got: %s", err)
+	}
+	if _, err := client.GetAccessToken(context.Background()); err != nil {
+		t.Fatalf("Expected no error getting access token. Got: %s", err)
+	}
+	return client
+}
+
+func TestServerRejectsInvalidCredentials(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	client, _ := paypalSdk.NewClient(ClientID, "wrong", server.URL)
+	if _, err := client.GetAccessToken(context.Background()); err == nil {
+		t.Errorf("Expected an error getting an access token with invalid credentials")
+	}
+}
+
+func TestServerProductLifecycle(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := testClient(t, server)
+
+	created, err := client.CreateProduct(context.Background(), paypalSdk.Product{
+		Name: "Product",
+		Type: paypalSdk.ProductTypeService,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error creating product. Got: %s", err)
+	}
+	if created.ID == "" || created.CreateTime == "" {
+		t.Errorf("Expected an ID and create time to be assigned. Got: %+v", created)
+	}
+
+	err = client.UpdateProduct(context.Background(), paypalSdk.Product{
+		ID:          created.ID,
+		Description: "Updated",
+		Type:        paypalSdk.ProductTypeService,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error updating product. Got: %s", err)
+	}
+
+	product, err := client.GetProduct(context.Background(), created.ID)
+	if err != nil {
+		t.Fatalf("Expected no error getting product. Got: %s", err)
+	}
+	if product.Name != "Product" || product.Description != "Updated" {
+		t.Errorf("Expected patched product. Got: %+v", product)
+	}
+
+	server.DeleteProduct(created.ID)
+	_, err = client.GetProduct(context.Background(), created.ID)
+	var errorResponse *paypalSdk.ErrorResponse
+	if !errors.As(err, &errorResponse) || errorResponse.Response.StatusCode != http.StatusNotFound || errorResponse.Name != "RESOURCE_NOT_FOUND" {
+		t.Errorf("Expected a RESOURCE_NOT_FOUND error. Got: %v", err)
+	}
+}
+
+func TestServerListProductsPagination(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := testClient(t, server)
+
+	for i := 0; i < 3; i++ {
+		if _, err := client.CreateProduct(context.Background(), paypalSdk.Product{Name: "Product", Type: paypalSdk.ProductTypeDigital}); err != nil {
+			t.Fatalf("Expected no error creating product. Got: %s", err)
+		}
+	}
+
+	page, err := client.ListProducts(context.Background(), &paypalSdk.ProductListParameters{
+		ListParams: paypalSdk.ListParams{Page: "2", PageSize: "2", TotalRequired: "true"},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error listing products. Got: %s", err)
+	}
+	if len(page.Products) != 1 || page.TotalItems != 3 || page.TotalPages != 2 {
+		t.Errorf("Expected the last page of 3 products. Got: %+v", page)
+	}
+}
+
+func TestServerSubscriptionPlanLifecycle(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := testClient(t, server)
+
+	created, err := client.CreateSubscriptionPlan(context.Background(), paypalSdk.SubscriptionPlan{
+		ProductId: "PROD-1",
+		Name:      "Plan",
+		BillingCycles: []paypalSdk.BillingCycle{{
+			Sequence:      1,
+			TenureType:    paypalSdk.TenureTypeRegular,
+			Frequency:     paypalSdk.Frequency{IntervalUnit: paypalSdk.IntervalUnitMonth, IntervalCount: 1},
+			PricingScheme: paypalSdk.PricingScheme{FixedPrice: paypalSdk.Money{Currency: "USD", Value: "1.00"}},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error creating plan. Got: %s", err)
+	}
+	if created.Status != paypalSdk.SubscriptionPlanStatusActive {
+		t.Errorf("Expected new plans to be active. Got: %s", created.Status)
+	}
+
+	err = client.UpdateSubscriptionPlanPricing(context.Background(), created.ID, []paypalSdk.PricingSchemeUpdate{{
+		BillingCycleSequence: 1,
+		PricingScheme:        paypalSdk.PricingScheme{FixedPrice: paypalSdk.Money{Currency: "USD", Value: "2.00"}},
+	}})
+	if err != nil {
+		t.Fatalf("Expected no error updating pricing. Got: %s", err)
+	}
+	if err := client.DeactivateSubscriptionPlans(context.Background(), created.ID); err != nil {
+		t.Fatalf("Expected no error deactivating plan. Got: %s", err)
+	}
+	if err := client.DeactivateSubscriptionPlans(context.Background(), created.ID); err == nil {
+		t.Errorf("Expected an error deactivating an inactive plan")
+	}
+
+	plan, err := client.GetSubscriptionPlan(context.Background(), created.ID)
+	if err != nil {
+		t.Fatalf("Expected no error getting plan. Got: %s", err)
+	}
+	pricingScheme := plan.BillingCycles[0].PricingScheme
+	if pricingScheme.FixedPrice.Value != "2.00" || pricingScheme.Version != 2 {
+		t.Errorf("Expected updated pricing scheme. Got: %+v", pricingScheme)
+	}
+	if plan.Status != paypalSdk.SubscriptionPlanStatusInactive {
+		t.Errorf("Expected deactivated plan. Got: %s", plan.Status)
+	}
+
+	list, err := client.ListSubscriptionPlans(context.Background(), &paypalSdk.SubscriptionPlanListParameters{ProductId: "PROD-2"})
+	if err != nil {
+		t.Fatalf("Expected no error listing plans. Got: %s", err)
+	}
+	if len(list.Plans) != 0 {
+		t.Errorf("Expected no plans for another product. Got: %+v", list.Plans)
+	}
+}
+
+func TestServerWebhookLifecycle(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := testClient(t, server)
+
+	created, err := client.CreateWebhook(context.Background(), &paypalSdk.CreateWebhookRequest{
+		URL:        "https://example.com/hook",
+		EventTypes: []paypalSdk.WebhookEventType{{Name: "PAYMENT.SALE.COMPLETED"}},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error creating webhook. Got: %s", err)
+	}
+
+	updated, err := client.UpdateWebhook(context.Background(), created.ID, []paypalSdk.WebhookField{{
+		Operation: "replace",
+		Path:      "/url",
+		Value:     "https://example.com/other",
+	}})
+	if err != nil {
+		t.Fatalf("Expected no error updating webhook. Got: %s", err)
+	}
+	if updated.URL != "https://example.com/other" || len(updated.EventTypes) != 1 {
+		t.Errorf("Expected updated webhook. Got: %+v", updated)
+	}
+
+	if err := client.DeleteWebhook(context.Background(), created.ID); err != nil {
+		t.Fatalf("Expected no error deleting webhook. Got: %s", err)
+	}
+	if server.Webhook(created.ID) != nil {
+		t.Errorf("Expected webhook to be deleted")
+	}
+}
+
+func TestServerWebhookEventTypes(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := testClient(t, server)
+
+	response, err := client.GetWebhookEventTypes(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error listing event types. Got: %s", err)
+	}
+	if len(response.EventTypes) != len(webhookEventTypes) || response.EventTypes[0].Name != "BILLING.SUBSCRIPTION.CREATED" {
+		t.Errorf("Expected the available event types. Got: %+v", response.EventTypes)
+	}
+}
+
+func TestServerVerifyWebhookSignature(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := testClient(t, server)
+
+	webhook, err := client.CreateWebhook(context.Background(), &paypalSdk.CreateWebhookRequest{
+		URL:        "https://example.com/hook",
+		EventTypes: []paypalSdk.WebhookEventType{{Name: "PAYMENT.SALE.COMPLETED"}},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error creating webhook. Got: %s", err)
+	}
+
+	for signature, expected := range map[string]string{
+		Sign("transmission-1", webhook.ID): "SUCCESS",
+		Sign("transmission-1", "WH-OTHER"): "FAILURE",
+	} {
+		delivery, _ := http.NewRequest(http.MethodPost, "https://example.com/hook", strings.NewReader(`{"id":"WH-EVENT-1"}`))
+		delivery.Header.Set("PAYPAL-AUTH-ALGO", "SHA256withRSA")
+		delivery.Header.Set("PAYPAL-CERT-URL", "https://api.paypal.com/cert.pem")
+		delivery.Header.Set("PAYPAL-TRANSMISSION-ID", "transmission-1")
+		delivery.Header.Set("PAYPAL-TRANSMISSION-SIG", signature)
+		delivery.Header.Set("PAYPAL-TRANSMISSION-TIME", "2021-01-01T00:00:00Z")
+
+		response, err := client.VerifyWebhookSignature(context.Background(), delivery, webhook.ID)
+		if err != nil {
+			t.Fatalf("Expected no error verifying signature. Got: %s", err)
+		}
+		if response.VerificationStatus != expected {
+			t.Errorf("Expected verification status %s. Got: %s", expected, response.VerificationStatus)
+		}
+	}
+}
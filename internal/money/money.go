@@ -0,0 +1,109 @@
+// Package money validates and compares PayPal money amounts. Amounts are kept as the decimal
+// strings PayPal uses, and are never converted to floats
+package money
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// currencyDecimals The currencies PayPal supports and the decimal places each allows
+// Doc: https://developer.paypal.com/docs/reports/reference/paypal-supported-currencies/
+var currencyDecimals = map[string]int{
+	"AUD": 2,
+	"BRL": 2,
+	"CAD": 2,
+	"CHF": 2,
+	"CNY": 2,
+	"CZK": 2,
+	"DKK": 2,
+	"EUR": 2,
+	"GBP": 2,
+	"HKD": 2,
+	"HUF": 0,
+	"ILS": 2,
+	"JPY": 0,
+	"MXN": 2,
+	"MYR": 2,
+	"NOK": 2,
+	"NZD": 2,
+	"PHP": 2,
+	"PLN": 2,
+	"RUB": 2,
+	"SEK": 2,
+	"SGD": 2,
+	"THB": 2,
+	"TWD": 0,
+	"USD": 2,
+}
+
+// valuePattern A non-negative decimal amount, as accepted by PayPal
+var valuePattern = regexp.MustCompile(`^[0-9]+(\.[0-9]+)?$`)
+
+// Currencies The ISO-4217 codes of every currency PayPal supports, sorted
+func Currencies() []string {
+	codes := make([]string, 0, len(currencyDecimals))
+	for code := range currencyDecimals {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// Decimals The number of decimal places a currency allows, and whether PayPal supports it
+func Decimals(currencyCode string) (int, bool) {
+	decimals, ok := currencyDecimals[currencyCode]
+	return decimals, ok
+}
+
+// ValidateCurrencyCode Check a currency code is one PayPal supports
+func ValidateCurrencyCode(currencyCode string) error {
+	if _, ok := currencyDecimals[currencyCode]; !ok {
+		return fmt.Errorf("%q is not a currency supported by PayPal. One of: %s", currencyCode, strings.Join(Currencies(), ","))
+	}
+	return nil
+}
+
+// ValidateValue Check a value is a non-negative decimal amount
+func ValidateValue(value string) error {
+	if !valuePattern.MatchString(value) {
+		return fmt.Errorf("%q is not a valid amount, expected a decimal such as 10.00", value)
+	}
+	return nil
+}
+
+// Validate Check a value is a valid amount in a currency, with no more decimal places than the currency allows
+func Validate(value, currencyCode string) error {
+	if err := ValidateCurrencyCode(currencyCode); err != nil {
+		return err
+	}
+	if err := ValidateValue(value); err != nil {
+		return err
+	}
+
+	decimals := currencyDecimals[currencyCode]
+	if i := strings.IndexByte(value, '.'); i >= 0 && len(value)-i-1 > decimals {
+		if decimals == 0 {
+			return fmt.Errorf("%q is not a valid %s amount, %s does not allow decimals", value, currencyCode, currencyCode)
+		}
+		return fmt.Errorf("%q is not a valid %s amount, %s allows at most %d decimal places", value, currencyCode, currencyCode, decimals)
+	}
+	return nil
+}
+
+// Equal Whether two decimal strings are the same amount, such as 10 and 10.00.
+// Values that are not decimals are only equal when they are identical
+func Equal(a, b string) bool {
+	if a == b {
+		return true
+	}
+	aRat, aOk := new(big.Rat).SetString(a)
+	bRat, bOk := new(big.Rat).SetString(b)
+	if !aOk || !bOk {
+		return false
+	}
+	return aRat.Cmp(bRat) == 0
+}
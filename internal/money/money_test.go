@@ -0,0 +1,89 @@
+package money
+
+import (
+	"testing"
+)
+
+func TestCurrencies(t *testing.T) {
+	currencies := Currencies()
+	if len(currencies) != len(currencyDecimals) {
+		t.Errorf("Expected every currency to be listed. Got: %v", currencies)
+	}
+	for i := 1; i < len(currencies); i++ {
+		if currencies[i-1] >= currencies[i] {
+			t.Errorf("Expected currencies to be sorted. Got: %v", currencies)
+		}
+	}
+}
+
+func TestDecimals(t *testing.T) {
+	testCases := map[string]struct {
+		decimals  int
+		supported bool
+	}{
+		"USD": {2, true},
+		"JPY": {0, true},
+		"HUF": {0, true},
+		"XXX": {0, false},
+		"usd": {0, false},
+	}
+	for currencyCode, expected := range testCases {
+		decimals, supported := Decimals(currencyCode)
+		if decimals != expected.decimals || supported != expected.supported {
+			t.Errorf("Expected %s to have %d decimals and supported %t. Got: %d %t", currencyCode, expected.decimals, expected.supported, decimals, supported)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	testCases := []struct {
+		value        string
+		currencyCode string
+		valid        bool
+	}{
+		{"10", "USD", true},
+		{"10.5", "USD", true},
+		{"10.50", "USD", true},
+		{"0", "USD", true},
+		{"10.505", "USD", false},
+		{"500", "JPY", true},
+		{"500.0", "JPY", false},
+		{"-1.00", "USD", false},
+		{"1,000.00", "USD", false},
+		{"1e3", "USD", false},
+		{"", "USD", false},
+		{"10.00", "XXX", false},
+		{"10.00", "", false},
+	}
+	for _, testCase := range testCases {
+		err := Validate(testCase.value, testCase.currencyCode)
+		if testCase.valid && err != nil {
+			t.Errorf("Expected %s %s to be valid. Got: %s", testCase.value, testCase.currencyCode, err)
+		}
+		if !testCase.valid && err == nil {
+			t.Errorf("Expected %s %s to be invalid", testCase.value, testCase.currencyCode)
+		}
+	}
+}
+
+func TestEqual(t *testing.T) {
+	testCases := []struct {
+		a, b  string
+		equal bool
+	}{
+		{"10", "10.00", true},
+		{"10.10", "10.1", true},
+		{"0.1", "0.10", true},
+		{"19.99", "19.990000000000000001", false},
+		{"10.00", "10.01", false},
+		{"", "", true},
+		{"", "0", false},
+		{"abc", "abc", true},
+		{"abc", "10", false},
+	}
+	for _, testCase := range testCases {
+		if equal := Equal(testCase.a, testCase.b); equal != testCase.equal {
+			t.Errorf("Expected Equal(%q, %q) to be %t. Got: %t", testCase.a, testCase.b, testCase.equal, equal)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package paypal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	paypalSdk "github.com/plutov/paypal/v4"
+)
+
+// PaypalClient The PayPal API calls used by the resources. Implemented by the SDK client,
+// and by anything wrapping it such as test fakes or decorators
+type PaypalClient interface {
+	// Notification webhooks
+	CreateWebhook(ctx context.Context, createWebhookRequest *paypalSdk.CreateWebhookRequest) (*paypalSdk.Webhook, error)
+	GetWebhook(ctx context.Context, webhookID string) (*paypalSdk.Webhook, error)
+	UpdateWebhook(ctx context.Context, webhookID string, fields []paypalSdk.WebhookField) (*paypalSdk.Webhook, error)
+	DeleteWebhook(ctx context.Context, webhookID string) error
+	ListWebhooks(ctx context.Context, anchorType string) (*paypalSdk.ListWebhookResponse, error)
+	GetWebhookEventTypes(ctx context.Context) (*paypalSdk.WebhookEventTypesResponse, error)
+	VerifyWebhookSignature(ctx context.Context, httpReq *http.Request, webhookID string) (*paypalSdk.VerifyWebhookResponse, error)
+	SimulateWebhookEvent(ctx context.Context, simulateRequest SimulateWebhookEventRequest) (*paypalSdk.Event, error)
+
+	// Catalog products
+	CreateProduct(ctx context.Context, product paypalSdk.Product) (*paypalSdk.CreateProductResponse, error)
+	GetProduct(ctx context.Context, productID string) (*paypalSdk.Product, error)
+	UpdateProduct(ctx context.Context, product paypalSdk.Product) error
+	ListProducts(ctx context.Context, params *paypalSdk.ProductListParameters) (*paypalSdk.ListProductsResponse, error)
+	GetProductDetails(ctx context.Context, productID string) (*ProductDetails, error)
+
+	// Subscription plans
+	CreateSubscriptionPlan(ctx context.Context, newPlan SubscriptionPlan) (*SubscriptionPlan, error)
+	GetSubscriptionPlan(ctx context.Context, planID string) (*SubscriptionPlan, error)
+	UpdateSubscriptionPlan(ctx context.Context, updatedPlan SubscriptionPlan) error
+	ListSubscriptionPlans(ctx context.Context, params *paypalSdk.SubscriptionPlanListParameters) (*paypalSdk.ListSubscriptionPlansResponse, error)
+	UpdateSubscriptionPlanPricing(ctx context.Context, planID string, pricingSchemes []PricingSchemeUpdate) error
+	ActivateSubscriptionPlan(ctx context.Context, planID string) error
+	DeactivateSubscriptionPlans(ctx context.Context, planID string) error
+}
+
+// Ensure the extended SDK client implements every call
+var _ PaypalClient = (*sdkClient)(nil)
+
+// listPageSize The largest page size PayPal allows when listing
+const listPageSize = 20
+
+// ProductDetails A catalog product with the timestamps the SDK's Product leaves out
+type ProductDetails struct {
+	paypalSdk.Product
+	CreateTime string `json:"create_time"`
+	UpdateTime string `json:"update_time"`
+}
+
+// sdkClient The SDK client, extended with the calls the SDK does not provide
+type sdkClient struct {
+	*paypalSdk.Client
+}
+
+// GetProductDetails Get a catalog product including its create and update times
+// Endpoint: GET /v1/catalogs/products/:product_id
+func (c *sdkClient) GetProductDetails(ctx context.Context, productID string) (*ProductDetails, error) {
+	req, err := c.NewRequest(ctx, http.MethodGet, fmt.Sprintf("%s/v1/catalogs/products/%s", c.APIBase, productID), nil)
+	response := &ProductDetails{}
+	if err != nil {
+		return response, err
+	}
+	err = c.SendWithAuth(req, response)
+	return response, err
+}
+
+// SimulateWebhookEventRequest A sample event to send to a webhook, by webhook ID or by URL
+// Doc: https://developer.paypal.com/docs/api/webhooks/v1/#simulate-event_post
+type SimulateWebhookEventRequest struct {
+	WebhookID       string `json:"webhook_id,omitempty"`
+	URL             string `json:"url,omitempty"`
+	EventType       string `json:"event_type"`
+	ResourceVersion string `json:"resource_version,omitempty"`
+}
+
+// SimulateWebhookEvent Send a sample event to a webhook, returning the event that was sent
+// Endpoint: POST /v1/notifications/simulate-event
+func (c *sdkClient) SimulateWebhookEvent(ctx context.Context, simulateRequest SimulateWebhookEventRequest) (*paypalSdk.Event, error) {
+	req, err := c.NewRequest(ctx, http.MethodPost, fmt.Sprintf("%s/v1/notifications/simulate-event", c.APIBase), simulateRequest)
+	response := &paypalSdk.Event{}
+	if err != nil {
+		return response, err
+	}
+	err = c.SendWithAuth(req, response)
+	return response, err
+}
+
+// ProviderMeta The configured provider state passed to every resource
+type ProviderMeta struct {
+	Client PaypalClient
+
+	// Environment The PayPal environment the client talks to: sandbox, live or custom
+	Environment string
+
+	// pendingCreates Request IDs of creates that got no response, for the creates replacing them to resend
+	pendingCreates pendingCreates
+}
+
+// isNotFound Whether an API error means the object no longer exists in PayPal
+func isNotFound(err error) bool {
+	var errorResponse *paypalSdk.ErrorResponse
+	if !errors.As(err, &errorResponse) {
+		return false
+	}
+	if errorResponse.Name == "RESOURCE_NOT_FOUND" {
+		return true
+	}
+	return errorResponse.Response != nil && errorResponse.Response.StatusCode == http.StatusNotFound
+}
+
+// listProducts Page through every catalog product. PayPal only lists the ID, name and description of each
+func listProducts(ctx context.Context, client PaypalClient) ([]paypalSdk.Product, error) {
+	products := []paypalSdk.Product{}
+	for page := 1; ; page++ {
+		response, err := client.ListProducts(ctx, &paypalSdk.ProductListParameters{
+			ListParams: paypalSdk.ListParams{
+				Page:          strconv.Itoa(page),
+				PageSize:      strconv.Itoa(listPageSize),
+				TotalRequired: "true",
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		products = append(products, response.Products...)
+		if page >= response.TotalPages || len(response.Products) == 0 {
+			return products, nil
+		}
+	}
+}
+
+// listSubscriptionPlans Page through every subscription plan, optionally only those for one product.
+// PayPal only lists the ID, product, name, status and description of each
+func listSubscriptionPlans(ctx context.Context, client PaypalClient, productID string) ([]paypalSdk.SubscriptionPlan, error) {
+	plans := []paypalSdk.SubscriptionPlan{}
+	for page := 1; ; page++ {
+		response, err := client.ListSubscriptionPlans(ctx, &paypalSdk.SubscriptionPlanListParameters{
+			ProductId: productID,
+			ListParams: paypalSdk.ListParams{
+				Page:          strconv.Itoa(page),
+				PageSize:      strconv.Itoa(listPageSize),
+				TotalRequired: "true",
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		plans = append(plans, response.Plans...)
+		if page >= response.TotalPages || len(response.Plans) == 0 {
+			return plans, nil
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package paypal
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	paypalSdk "github.com/plutov/paypal/v4"
+)
+
+// The SDK's subscription plan types only support fixed pricing, so plans are sent and
+// received with these instead. Doc: https://developer.paypal.com/docs/api/subscriptions/v1/#plans_create
+type (
+	// SubscriptionPlan A subscription plan supporting every PayPal pricing model
+	SubscriptionPlan struct {
+		ID                 string                           `json:"id,omitempty"`
+		ProductId          string                           `json:"product_id"`
+		Name               string                           `json:"name"`
+		Status             paypalSdk.SubscriptionPlanStatus `json:"status,omitempty"`
+		Description        string                           `json:"description,omitempty"`
+		BillingCycles      []BillingCycle                   `json:"billing_cycles"`
+		PaymentPreferences *PaymentPreferences              `json:"payment_preferences,omitempty"`
+		Taxes              *paypalSdk.Taxes                 `json:"taxes,omitempty"`
+		QuantitySupported  bool                             `json:"quantity_supported"`
+	}
+
+	// BillingCycle Doc: https://developer.paypal.com/docs/api/subscriptions/v1/#definition-billing_cycle
+	BillingCycle struct {
+		PricingScheme PricingScheme        `json:"pricing_scheme"`
+		Frequency     paypalSdk.Frequency  `json:"frequency"`
+		TenureType    paypalSdk.TenureType `json:"tenure_type"`
+		Sequence      int                  `json:"sequence"`
+		TotalCycles   int                  `json:"total_cycles"`
+	}
+
+	// PricingScheme Either a fixed price, or tiers for the VOLUME and TIERED pricing models
+	// Doc: https://developer.paypal.com/docs/api/subscriptions/v1/#definition-pricing_scheme
+	PricingScheme struct {
+		Version      int              `json:"version,omitempty"`
+		PricingModel string           `json:"pricing_model,omitempty"`
+		FixedPrice   *paypalSdk.Money `json:"fixed_price,omitempty"`
+		Tiers        []PricingTier    `json:"tiers,omitempty"`
+	}
+
+	// PricingTier The price for a range of quantities. The last tier has no ending quantity
+	// Doc: https://developer.paypal.com/docs/api/subscriptions/v1/#definition-pricing_tier
+	PricingTier struct {
+		StartingQuantity string          `json:"starting_quantity"`
+		EndingQuantity   string          `json:"ending_quantity,omitempty"`
+		Amount           paypalSdk.Money `json:"amount"`
+	}
+
+	// PaymentPreferences Unset preferences are left out, so PayPal applies its defaults
+	// Doc: https://developer.paypal.com/docs/api/subscriptions/v1/#definition-payment_preferences
+	PaymentPreferences struct {
+		AutoBillOutstanding     bool                            `json:"auto_bill_outstanding"`
+		SetupFee                *paypalSdk.Money                `json:"setup_fee,omitempty"`
+		SetupFeeFailureAction   paypalSdk.SetupFeeFailureAction `json:"setup_fee_failure_action,omitempty"`
+		PaymentFailureThreshold int                             `json:"payment_failure_threshold,omitempty"`
+	}
+
+	// PricingSchemeUpdate A new pricing scheme for one billing cycle
+	PricingSchemeUpdate struct {
+		BillingCycleSequence int           `json:"billing_cycle_sequence"`
+		PricingScheme        PricingScheme `json:"pricing_scheme"`
+	}
+)
+
+// CreateSubscriptionPlan creates a subscription plan
+// Endpoint: POST /v1/billing/plans
+func (c *sdkClient) CreateSubscriptionPlan(ctx context.Context, newPlan SubscriptionPlan) (*SubscriptionPlan, error) {
+	req, err := c.NewRequest(ctx, http.MethodPost, fmt.Sprintf("%s/v1/billing/plans", c.APIBase), newPlan)
+	response := &SubscriptionPlan{}
+	if err != nil {
+		return response, err
+	}
+	err = c.SendWithAuth(req, response)
+	return response, err
+}
+
+// GetSubscriptionPlan gets a subscription plan
+// Endpoint: GET /v1/billing/plans/:plan_id
+func (c *sdkClient) GetSubscriptionPlan(ctx context.Context, planID string) (*SubscriptionPlan, error) {
+	req, err := c.NewRequest(ctx, http.MethodGet, fmt.Sprintf("%s/v1/billing/plans/%s", c.APIBase, planID), nil)
+	response := &SubscriptionPlan{}
+	if err != nil {
+		return response, err
+	}
+	err = c.SendWithAuth(req, response)
+	return response, err
+}
+
+// UpdateSubscriptionPlan patches the description, taxes and payment preferences of a plan
+// Endpoint: PATCH /v1/billing/plans/:plan_id
+func (c *sdkClient) UpdateSubscriptionPlan(ctx context.Context, updatedPlan SubscriptionPlan) error {
+	plan := paypalSdk.SubscriptionPlan{
+		ID:          updatedPlan.ID,
+		Description: updatedPlan.Description,
+		Taxes:       updatedPlan.Taxes,
+	}
+	if updatedPlan.PaymentPreferences != nil {
+		plan.PaymentPreferences = &paypalSdk.PaymentPreferences{
+			AutoBillOutstanding:     updatedPlan.PaymentPreferences.AutoBillOutstanding,
+			SetupFee:                updatedPlan.PaymentPreferences.SetupFee,
+			SetupFeeFailureAction:   updatedPlan.PaymentPreferences.SetupFeeFailureAction,
+			PaymentFailureThreshold: updatedPlan.PaymentPreferences.PaymentFailureThreshold,
+		}
+	}
+	return c.Client.UpdateSubscriptionPlan(ctx, plan)
+}
+
+// UpdateSubscriptionPlanPricing updates the pricing schemes of a plan's billing cycles
+// Endpoint: POST /v1/billing/plans/:plan_id/update-pricing-schemes
+func (c *sdkClient) UpdateSubscriptionPlanPricing(ctx context.Context, planID string, pricingSchemes []PricingSchemeUpdate) error {
+	req, err := c.NewRequest(ctx, http.MethodPost, fmt.Sprintf("%s/v1/billing/plans/%s/update-pricing-schemes", c.APIBase, planID), map[string]interface{}{
+		"pricing_schemes": pricingSchemes,
+	})
+	if err != nil {
+		return err
+	}
+	return c.SendWithAuth(req, nil)
+}
@@ -0,0 +1,82 @@
+package paypal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/ollieparsley/terraform-provider-paypal/internal/fakepaypal"
+	paypalSdk "github.com/plutov/paypal/v4"
+)
+
+// stubProductClient Serves a single product without touching the network
+type stubProductClient struct {
+	PaypalClient
+	product paypalSdk.Product
+	calls   int
+}
+
+func (c *stubProductClient) GetProduct(ctx context.Context, productID string) (*paypalSdk.Product, error) {
+	c.calls++
+	product := c.product
+	product.ID = productID
+	return &product, nil
+}
+
+func TestProviderMetaClientCanBeInjected(t *testing.T) {
+	client := &stubProductClient{product: paypalSdk.Product{
+		Name: "Stubbed product",
+		Type: paypalSdk.ProductTypeDigital,
+	}}
+	meta := &ProviderMeta{Client: client}
+
+	resource := CatalogProductResource{}
+	d := schema.TestResourceDataRaw(t, resource.Schema(), map[string]interface{}{})
+	d.SetId("PROD-1")
+
+	if diags := resource.Read(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("Expected no error reading product. Got: %+v", diags)
+	}
+	if client.calls != 1 || d.Get("name") != "Stubbed product" || d.Get("type") != "digital" {
+		t.Errorf("Expected product to be read through the injected client. Got %d calls and: %+v", client.calls, d.State())
+	}
+}
+
+func TestProviderConfigureReturnsMeta(t *testing.T) {
+	server := fakepaypal.NewServer()
+	defer server.Close()
+
+	meta, ok := testProviderMeta(t, server).(*ProviderMeta)
+	if !ok || meta.Client == nil {
+		t.Fatalf("Expected provider meta with a client. Got: %+v", meta)
+	}
+	if _, ok := meta.Client.(*sdkClient); !ok {
+		t.Errorf("Expected the extended SDK client to be used. Got: %T", meta.Client)
+	}
+}
+
+func TestIsNotFound(t *testing.T) {
+	notFoundResponse := &http.Response{StatusCode: http.StatusNotFound}
+	serverErrorResponse := &http.Response{StatusCode: http.StatusInternalServerError}
+
+	cases := map[string]struct {
+		err      error
+		expected bool
+	}{
+		"nil":                {err: nil, expected: false},
+		"other error":        {err: errors.New("boom"), expected: false},
+		"resource not found": {err: &paypalSdk.ErrorResponse{Name: "RESOURCE_NOT_FOUND", Response: serverErrorResponse}, expected: true},
+		"404 status":         {err: &paypalSdk.ErrorResponse{Response: notFoundResponse}, expected: true},
+		"wrapped 404":        {err: fmt.Errorf("wrapped: %w", &paypalSdk.ErrorResponse{Response: notFoundResponse}), expected: true},
+		"server error":       {err: &paypalSdk.ErrorResponse{Name: "INTERNAL_SERVER_ERROR", Response: serverErrorResponse}, expected: false},
+	}
+
+	for name, c := range cases {
+		if actual := isNotFound(c.err); actual != c.expected {
+			t.Errorf("%s: expected isNotFound to be %t. Got: %t", name, c.expected, actual)
+		}
+	}
+}
@@ -1,7 +1,12 @@
 package paypal
 
 import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"log"
+	"net/http"
+	"time"
 
 	paypalSdk "github.com/plutov/paypal/v4"
 )
@@ -10,19 +15,103 @@ import (
 type Config struct {
 	ClientID     string
 	ClientSecret string
+	AccessToken  string
 	BaseURL      string
+	MaxRetries   int
+	RetryMinWait time.Duration
+	RetryMaxWait time.Duration
+
+	// PartnerAttributionID The BN code PayPal partners send to attribute calls to their platform
+	PartnerAttributionID string
+
+	// ActAsMerchantID and ActAsEmail The merchant a partner makes calls on behalf of, by payer ID or by email
+	ActAsMerchantID string
+	ActAsEmail      string
 }
 
 // Client returns a new Client for accessing Paypal by using the access token
 func (c *Config) Client() (*paypalSdk.Client, error) {
 
-	// Create a client instance
-	client, err := paypalSdk.NewClient(c.ClientID, c.ClientSecret, c.BaseURL)
-	if err != nil {
-		return nil, err
+	// Create a client instance. The SDK requires client credentials, so a client using
+	// only an access token is built directly
+	var client *paypalSdk.Client
+	if c.AccessToken != "" {
+		if c.BaseURL == "" {
+			return nil, errors.New("APIBase is required to create a Client")
+		}
+		client = &paypalSdk.Client{APIBase: c.BaseURL}
+		client.SetAccessToken(c.AccessToken)
+	} else {
+		var err error
+		client, err = paypalSdk.NewClient(c.ClientID, c.ClientSecret, c.BaseURL)
+		if err != nil {
+			return nil, err
+		}
 	}
-	//client.SetAccessToken(c.AccessToken)
+
+	// Send request IDs with creates, and retry rate limited and failed requests
+	client.SetHTTPClient(&http.Client{
+		Transport: &headerTransport{
+			headers: c.headers(),
+			transport: &requestIDTransport{
+				transport: &retryTransport{
+					transport:  http.DefaultTransport,
+					maxRetries: c.MaxRetries,
+					minWait:    c.RetryMinWait,
+					maxWait:    c.RetryMaxWait,
+				},
+			},
+		},
+	})
+
 	log.Printf("[INFO] Paypal Client configured.")
 
 	return client, nil
 }
+
+// headers The headers sent with every request
+func (c *Config) headers() http.Header {
+	headers := http.Header{}
+	if c.PartnerAttributionID != "" {
+		headers.Set("PayPal-Partner-Attribution-Id", c.PartnerAttributionID)
+	}
+	if c.ActAsMerchantID != "" || c.ActAsEmail != "" {
+		headers.Set("PayPal-Auth-Assertion", c.authAssertion())
+	}
+	return headers
+}
+
+// authAssertion An unsigned JWT naming the merchant to act on behalf of
+// Doc: https://developer.paypal.com/docs/api/reference/api-requests/#paypal-auth-assertion
+func (c *Config) authAssertion() string {
+	header, _ := json.Marshal(map[string]string{"alg": "none"})
+
+	claims := map[string]string{"iss": c.ClientID}
+	if c.ActAsMerchantID != "" {
+		claims["payer_id"] = c.ActAsMerchantID
+	} else {
+		claims["email"] = c.ActAsEmail
+	}
+	payload, _ := json.Marshal(claims)
+
+	return base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload) + "."
+}
+
+// headerTransport Adds the configured headers to every request
+type headerTransport struct {
+	transport http.RoundTripper
+	headers   http.Header
+}
+
+// RoundTrip Send the request with the configured headers
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if len(t.headers) == 0 {
+		return t.transport.RoundTrip(req)
+	}
+
+	req = req.Clone(req.Context())
+	for key, values := range t.headers {
+		req.Header[key] = append([]string{}, values...)
+	}
+	return t.transport.RoundTrip(req)
+}
@@ -0,0 +1,67 @@
+package paypal
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/ollieparsley/terraform-provider-paypal/internal/fakepaypal"
+)
+
+func TestConfigAuthAssertion(t *testing.T) {
+	testCases := map[string]struct {
+		config   Config
+		expected string
+	}{
+		"merchant ID": {
+			config:   Config{ClientID: "client-1", ActAsMerchantID: "MERCHANT1"},
+			expected: `{"iss":"client-1","payer_id":"MERCHANT1"}`,
+		},
+		"email": {
+			config:   Config{ClientID: "client-1", ActAsEmail: "seller@example.com"},
+			expected: `{"email":"seller@example.com","iss":"client-1"}`,
+		},
+	}
+	for name, testCase := range testCases {
+		parts := strings.Split(testCase.config.authAssertion(), ".")
+		if len(parts) != 3 || parts[2] != "" {
+			t.Fatalf("%s: Expected an unsigned JWT. Got: %v", name, parts)
+		}
+
+		header, _ := base64.RawURLEncoding.DecodeString(parts[0])
+		payload, _ := base64.RawURLEncoding.DecodeString(parts[1])
+		if differences := deep.Equal([]string{`{"alg":"none"}`, testCase.expected}, []string{string(header), string(payload)}); len(differences) > 0 {
+			t.Errorf("%s: Expected assertion to name the merchant. Got differences: %+v", name, differences)
+		}
+	}
+}
+
+func TestConfigClientSendsAuthAssertion(t *testing.T) {
+	server := fakepaypal.NewServer()
+	defer server.Close()
+
+	config := Config{
+		ClientID:        fakepaypal.ClientID,
+		ClientSecret:    fakepaypal.ClientSecret,
+		BaseURL:         server.URL,
+		ActAsMerchantID: "MERCHANT1",
+	}
+	client, err := config.Client()
+	if err != nil {
+		t.Fatalf("Expected no error creating client. Got: %s", err)
+	}
+	if _, err := client.GetAccessToken(context.Background()); err != nil {
+		t.Fatalf("Expected no error getting access token. Got: %s", err)
+	}
+	if _, err := client.ListWebhooks(context.Background(), ""); err != nil {
+		t.Fatalf("Expected no error listing webhooks. Got: %s", err)
+	}
+
+	for _, request := range server.Requests() {
+		if actual := request.Header.Get("PayPal-Auth-Assertion"); actual != config.authAssertion() {
+			t.Errorf("Expected %s %s to act as the merchant. Got: %q", request.Method, request.Path, actual)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package paypal
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"log"
+)
+
+type CatalogProductDataSource struct{}
+
+func (r CatalogProductDataSource) DataSource() *schema.Resource {
+	return &schema.Resource{
+		Schema:      r.Schema(),
+		ReadContext: r.Read,
+	}
+}
+
+func (r CatalogProductDataSource) Schema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"id": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ExactlyOneOf: []string{"id", "name"},
+			Description:  "The ID of the product to look up",
+		},
+		"name": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ExactlyOneOf: []string{"id", "name"},
+			Description:  "The exact name of the product to look up. Exactly one product must have this name",
+		},
+		"description": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The description of the product",
+		},
+		"image_url": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "An externally hosted image of the product",
+		},
+		"home_url": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "A URL to product information",
+		},
+		"type": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The product type. One of: physical,digital,service",
+		},
+		"category": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The product category",
+		},
+		"environment": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The PayPal environment this product belongs to: sandbox, live or custom",
+		},
+		"create_time": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "When the product was created",
+		},
+		"update_time": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "When the product was last updated",
+		},
+	}
+}
+
+// Read - Look up a catalog product in Paypal by ID or name
+func (r CatalogProductDataSource) Read(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*ProviderMeta).Client
+
+	productID := d.Get("id").(string)
+	if productID == "" {
+		name := d.Get("name").(string)
+		products, err := listProducts(ctx, client)
+		if err != nil {
+			log.Printf("Error listing catalog products: %s", err.Error())
+			return diag.FromErr(err)
+		}
+
+		matchingIDs := []string{}
+		for _, product := range products {
+			if product.Name == name {
+				matchingIDs = append(matchingIDs, product.ID)
+			}
+		}
+		if len(matchingIDs) == 0 {
+			return diag.Errorf("no catalog product found with name %q", name)
+		}
+		if len(matchingIDs) > 1 {
+			return diag.Errorf("%d catalog products found with name %q: %s", len(matchingIDs), name, strings.Join(matchingIDs, ","))
+		}
+		productID = matchingIDs[0]
+	}
+
+	product, err := client.GetProductDetails(ctx, productID)
+	if isNotFound(err) {
+		return diag.Errorf("no catalog product found with ID %q", productID)
+	}
+	if err != nil {
+		log.Printf("Error getting catalog product %s: %s", productID, err.Error())
+		return diag.FromErr(err)
+	}
+
+	d.SetId(product.ID)
+	d.Set("name", product.Name)
+	d.Set("environment", m.(*ProviderMeta).Environment)
+	d.Set("description", product.Description)
+	d.Set("image_url", product.ImageUrl)
+	d.Set("home_url", product.HomeUrl)
+	d.Set("type", strings.ToLower(string(product.Type)))
+	d.Set("category", string(product.Category))
+	d.Set("create_time", product.CreateTime)
+	d.Set("update_time", product.UpdateTime)
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package paypal
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/ollieparsley/terraform-provider-paypal/internal/fakepaypal"
+	paypalSdk "github.com/plutov/paypal/v4"
+)
+
+// testCreateProducts Create products directly in the fake API, returning their IDs
+func testCreateProducts(t *testing.T, meta interface{}, products ...paypalSdk.Product) []string {
+	t.Helper()
+
+	ids := []string{}
+	for _, product := range products {
+		created, err := meta.(*ProviderMeta).Client.CreateProduct(context.Background(), product)
+		if err != nil {
+			t.Fatalf("Expected no error creating product. Got: %s", err)
+		}
+		ids = append(ids, created.ID)
+	}
+	return ids
+}
+
+func TestCatalogProductDataSourceByID(t *testing.T) {
+	server := fakepaypal.NewServer()
+	defer server.Close()
+	meta := testProviderMeta(t, server)
+
+	ids := testCreateProducts(t, meta, paypalSdk.Product{
+		Name:     "Product",
+		Type:     paypalSdk.ProductTypeService,
+		Category: paypalSdk.ProductCategorySoftware,
+		HomeUrl:  "https://example.com",
+	})
+
+	dataSource := CatalogProductDataSource{}
+	d := schema.TestResourceDataRaw(t, dataSource.Schema(), map[string]interface{}{"id": ids[0]})
+	if diags := dataSource.Read(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("Expected no error reading product. Got: %+v", diags)
+	}
+
+	if d.Id() != ids[0] || d.Get("name") != "Product" || d.Get("type") != "service" || d.Get("category") != "SOFTWARE" || d.Get("home_url") != "https://example.com" || d.Get("environment") != "custom" {
+		t.Errorf("Expected product attributes to be set. Got: %+v", d.State())
+	}
+	if d.Get("create_time") == "" || d.Get("update_time") == "" {
+		t.Errorf("Expected product timestamps to be set. Got: %+v", d.State())
+	}
+}
+
+func TestCatalogProductDataSourceByNameAcrossPages(t *testing.T) {
+	server := fakepaypal.NewServer()
+	defer server.Close()
+	meta := testProviderMeta(t, server)
+
+	products := []paypalSdk.Product{}
+	for i := 0; i < listPageSize+5; i++ {
+		products = append(products, paypalSdk.Product{Name: fmt.Sprintf("Product %d", i), Type: paypalSdk.ProductTypeDigital})
+	}
+	ids := testCreateProducts(t, meta, products...)
+
+	dataSource := CatalogProductDataSource{}
+	d := schema.TestResourceDataRaw(t, dataSource.Schema(), map[string]interface{}{"name": fmt.Sprintf("Product %d", listPageSize+3)})
+	if diags := dataSource.Read(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("Expected no error reading product. Got: %+v", diags)
+	}
+	if d.Id() != ids[listPageSize+3] || d.Get("type") != "digital" {
+		t.Errorf("Expected product on the second page to be found. Got: %+v", d.State())
+	}
+}
+
+func TestCatalogProductDataSourceErrors(t *testing.T) {
+	server := fakepaypal.NewServer()
+	defer server.Close()
+	meta := testProviderMeta(t, server)
+
+	testCreateProducts(t, meta,
+		paypalSdk.Product{Name: "Duplicate", Type: paypalSdk.ProductTypeDigital},
+		paypalSdk.Product{Name: "Duplicate", Type: paypalSdk.ProductTypeDigital},
+	)
+
+	dataSource := CatalogProductDataSource{}
+	for _, config := range []map[string]interface{}{
+		{"id": "PROD-MISSING"},
+		{"name": "Missing"},
+		{"name": "Duplicate"},
+	} {
+		d := schema.TestResourceDataRaw(t, dataSource.Schema(), config)
+		if diags := dataSource.Read(context.Background(), d, meta); !diags.HasError() {
+			t.Errorf("Expected an error looking up %v", config)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package paypal
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"log"
+)
+
+type CatalogProductsDataSource struct{}
+
+func (r CatalogProductsDataSource) DataSource() *schema.Resource {
+	return &schema.Resource{
+		Schema:      r.Schema(),
+		ReadContext: r.Read,
+	}
+}
+
+func (r CatalogProductsDataSource) Schema() map[string]*schema.Schema {
+	productTypes := CatalogProductResource{}.productTypes()
+
+	return map[string]*schema.Schema{
+		"type": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringInSlice(productTypes, true),
+			Description:  fmt.Sprintf("Only include products of this type. One of: %s", strings.Join(productTypes, ",")),
+		},
+		"category": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Only include products in this category",
+		},
+		"ids": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "The IDs of the matching products",
+		},
+		"products": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The matching products",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"id": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The ID of the product",
+					},
+					"name": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The name of the product",
+					},
+					"description": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The description of the product",
+					},
+					"image_url": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "An externally hosted image of the product",
+					},
+					"home_url": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "A URL to product information",
+					},
+					"type": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The product type",
+					},
+					"category": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The product category",
+					},
+					"create_time": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "When the product was created",
+					},
+					"update_time": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "When the product was last updated",
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read - List catalog products in Paypal. The list API only returns names and descriptions,
+// so each product is fetched to filter on type and category
+func (r CatalogProductsDataSource) Read(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*ProviderMeta).Client
+
+	productType := d.Get("type").(string)
+	category := d.Get("category").(string)
+
+	summaries, err := listProducts(ctx, client)
+	if err != nil {
+		log.Printf("Error listing catalog products: %s", err.Error())
+		return diag.FromErr(err)
+	}
+
+	ids := []string{}
+	products := []map[string]interface{}{}
+	for _, summary := range summaries {
+		product, err := client.GetProductDetails(ctx, summary.ID)
+		if err != nil {
+			log.Printf("Error getting catalog product %s: %s", summary.ID, err.Error())
+			return diag.FromErr(err)
+		}
+
+		if productType != "" && !strings.EqualFold(productType, string(product.Type)) {
+			continue
+		}
+		if category != "" && !strings.EqualFold(category, string(product.Category)) {
+			continue
+		}
+
+		ids = append(ids, product.ID)
+		products = append(products, map[string]interface{}{
+			"id":          product.ID,
+			"name":        product.Name,
+			"description": product.Description,
+			"image_url":   product.ImageUrl,
+			"home_url":    product.HomeUrl,
+			"type":        strings.ToLower(string(product.Type)),
+			"category":    string(product.Category),
+			"create_time": product.CreateTime,
+			"update_time": product.UpdateTime,
+		})
+	}
+
+	d.SetId(fmt.Sprintf("products/%s/%s", strings.ToLower(productType), strings.ToUpper(category)))
+	d.Set("ids", ids)
+	d.Set("products", products)
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package paypal
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/ollieparsley/terraform-provider-paypal/internal/fakepaypal"
+	paypalSdk "github.com/plutov/paypal/v4"
+)
+
+func TestCatalogProductsDataSourceFilters(t *testing.T) {
+	server := fakepaypal.NewServer()
+	defer server.Close()
+	meta := testProviderMeta(t, server)
+
+	products := []paypalSdk.Product{}
+	for i := 0; i < listPageSize; i++ {
+		products = append(products, paypalSdk.Product{Name: "Physical", Type: paypalSdk.ProductTypePhysical})
+	}
+	products = append(products,
+		paypalSdk.Product{Name: "Game", Type: paypalSdk.ProductTypeDigital, Category: paypalSdk.ProductCategorySoftwareGames},
+		paypalSdk.Product{Name: "Software", Type: paypalSdk.ProductTypeDigital, Category: paypalSdk.ProductCategorySoftware},
+	)
+	ids := testCreateProducts(t, meta, products...)
+
+	dataSource := CatalogProductsDataSource{}
+	cases := []struct {
+		config   map[string]interface{}
+		expected []interface{}
+	}{
+		{config: map[string]interface{}{"type": "digital"}, expected: []interface{}{ids[listPageSize], ids[listPageSize+1]}},
+		{config: map[string]interface{}{"type": "DIGITAL", "category": "software"}, expected: []interface{}{ids[listPageSize+1]}},
+		{config: map[string]interface{}{"type": "service"}, expected: []interface{}{}},
+	}
+
+	for _, c := range cases {
+		d := schema.TestResourceDataRaw(t, dataSource.Schema(), c.config)
+		if diags := dataSource.Read(context.Background(), d, meta); diags.HasError() {
+			t.Fatalf("Expected no error listing products. Got: %+v", diags)
+		}
+		if differences := deep.Equal(c.expected, d.Get("ids")); len(differences) > 0 {
+			t.Errorf("Expected matching product IDs for %v. Got differences: %+v", c.config, differences)
+		}
+	}
+
+	d := schema.TestResourceDataRaw(t, dataSource.Schema(), map[string]interface{}{})
+	if diags := dataSource.Read(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("Expected no error listing products. Got: %+v", diags)
+	}
+	if count := d.Get("products.#"); count != len(ids) {
+		t.Errorf("Expected every product without filters. Got: %v", count)
+	}
+	if d.Get("products.20.category") != "GAMES" || d.Get("products.20.type") != "digital" {
+		t.Errorf("Expected product details to be set. Got: %+v", d.Get("products.20"))
+	}
+}
+
+func TestCatalogProductsDataSourceTypeValidation(t *testing.T) {
+	validateFunc := CatalogProductsDataSource{}.Schema()["type"].ValidateFunc
+	if _, errs := validateFunc("subscription", "type"); len(errs) == 0 {
+		t.Errorf("Expected an unknown product type to be invalid")
+	}
+	if _, errs := validateFunc("PHYSICAL", "type"); len(errs) > 0 {
+		t.Errorf("Expected product types to be case insensitive. Got: %v", errs)
+	}
+}
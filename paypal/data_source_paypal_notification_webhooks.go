@@ -0,0 +1,124 @@
+package paypal
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	paypalSdk "github.com/plutov/paypal/v4"
+
+	"log"
+)
+
+type NotificationWebhooksDataSource struct{}
+
+func (r NotificationWebhooksDataSource) DataSource() *schema.Resource {
+	return &schema.Resource{
+		Schema:      r.Schema(),
+		ReadContext: r.Read,
+	}
+}
+
+func (r NotificationWebhooksDataSource) Schema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"url_prefix": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Only include webhooks whose URL starts with this prefix",
+		},
+		"event_type": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			ValidateDiagFunc: validateWebhookEventType,
+			Description:      "Only include webhooks that receive this event type, including webhooks subscribed to *",
+		},
+		"ids": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "The IDs of the matching webhooks",
+		},
+		"webhooks": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The matching webhooks",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"id": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The ID of the webhook",
+					},
+					"url": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The URL that Paypal sends notifications to",
+					},
+					"event_types": {
+						Type:        schema.TypeList,
+						Computed:    true,
+						Elem:        &schema.Schema{Type: schema.TypeString},
+						Description: "The event types the webhook receives",
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read - List the notification webhooks of the app - https://developer.paypal.com/docs/api/webhooks/v1/#webhooks_list
+func (r NotificationWebhooksDataSource) Read(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*ProviderMeta).Client
+
+	urlPrefix := d.Get("url_prefix").(string)
+	eventType := d.Get("event_type").(string)
+	if eventType != "" {
+		if err := checkWebhookEventTypes(ctx, client, "event_type", []string{eventType}); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	response, err := client.ListWebhooks(ctx, paypalSdk.AncorTypeApplication)
+	if err != nil {
+		log.Printf("Error listing notifications webhooks: %s", err.Error())
+		return diag.FromErr(err)
+	}
+
+	ids := []string{}
+	webhooks := []map[string]interface{}{}
+	for _, webhook := range response.Webhooks {
+		if !strings.HasPrefix(webhook.URL, urlPrefix) {
+			continue
+		}
+		eventTypeNames := WebhookResource{}.eventTypesToEventTypeNames(webhook.EventTypes)
+		if eventType != "" && !r.receives(eventTypeNames, eventType) {
+			continue
+		}
+
+		ids = append(ids, webhook.ID)
+		webhooks = append(webhooks, map[string]interface{}{
+			"id":          webhook.ID,
+			"url":         webhook.URL,
+			"event_types": eventTypeNames,
+		})
+	}
+
+	d.SetId(fmt.Sprintf("webhooks/%s/%s", urlPrefix, strings.ToUpper(eventType)))
+	d.Set("ids", ids)
+	d.Set("webhooks", webhooks)
+
+	return nil
+}
+
+// receives Whether a webhook subscribed to these event types receives the event type
+func (r NotificationWebhooksDataSource) receives(eventTypeNames []string, eventType string) bool {
+	for _, eventTypeName := range eventTypeNames {
+		if eventTypeName == webhookEventTypeAll || strings.EqualFold(eventTypeName, eventType) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,70 @@
+package paypal
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/ollieparsley/terraform-provider-paypal/internal/fakepaypal"
+	paypalSdk "github.com/plutov/paypal/v4"
+)
+
+// testCreateWebhook Create a webhook directly in the fake API, returning its ID
+func testCreateWebhook(t *testing.T, meta interface{}, url string, eventTypeNames ...string) string {
+	t.Helper()
+
+	webhook, err := meta.(*ProviderMeta).Client.CreateWebhook(context.Background(), &paypalSdk.CreateWebhookRequest{
+		URL:        url,
+		EventTypes: WebhookResource{}.eventTypeNamesToEventTypes(eventTypeNames),
+	})
+	if err != nil {
+		t.Fatalf("Expected no error creating webhook. Got: %s", err)
+	}
+	return webhook.ID
+}
+
+func TestNotificationWebhooksDataSourceFilters(t *testing.T) {
+	server := fakepaypal.NewServer()
+	defer server.Close()
+	meta := testProviderMeta(t, server)
+
+	sales := testCreateWebhook(t, meta, "https://staging.example.com/paypal", "PAYMENT.SALE.COMPLETED")
+	disputes := testCreateWebhook(t, meta, "https://staging.example.com/disputes", "CUSTOMER.DISPUTE.CREATED")
+	all := testCreateWebhook(t, meta, "https://example.com/paypal", "*")
+
+	dataSource := NotificationWebhooksDataSource{}
+	cases := []struct {
+		config   map[string]interface{}
+		expected []interface{}
+	}{
+		{config: map[string]interface{}{}, expected: []interface{}{sales, disputes, all}},
+		{config: map[string]interface{}{"url_prefix": "https://staging.example.com/"}, expected: []interface{}{sales, disputes}},
+		{config: map[string]interface{}{"event_type": "payment.sale.completed"}, expected: []interface{}{sales, all}},
+		{config: map[string]interface{}{"url_prefix": "https://staging.", "event_type": "CUSTOMER.DISPUTE.CREATED"}, expected: []interface{}{disputes}},
+		{config: map[string]interface{}{"url_prefix": "https://production."}, expected: []interface{}{}},
+	}
+
+	for _, c := range cases {
+		d := schema.TestResourceDataRaw(t, dataSource.Schema(), c.config)
+		if diags := dataSource.Read(context.Background(), d, meta); diags.HasError() {
+			t.Fatalf("Expected no error listing webhooks. Got: %+v", diags)
+		}
+		if differences := deep.Equal(c.expected, d.Get("ids")); len(differences) > 0 {
+			t.Errorf("Expected matching webhook IDs for %v. Got differences: %+v", c.config, differences)
+		}
+	}
+
+	d := schema.TestResourceDataRaw(t, dataSource.Schema(), map[string]interface{}{"url_prefix": "https://staging.example.com/disputes"})
+	if diags := dataSource.Read(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("Expected no error listing webhooks. Got: %+v", diags)
+	}
+	if d.Get("webhooks.0.id") != disputes || d.Get("webhooks.0.url") != "https://staging.example.com/disputes" || d.Get("webhooks.0.event_types.0") != "CUSTOMER.DISPUTE.CREATED" {
+		t.Errorf("Expected webhook attributes to be set. Got: %+v", d.Get("webhooks"))
+	}
+
+	d = schema.TestResourceDataRaw(t, dataSource.Schema(), map[string]interface{}{"event_type": "PAYMENT.SALE.COMPLETD"})
+	if diags := dataSource.Read(context.Background(), d, meta); !diags.HasError() {
+		t.Errorf("Expected an error filtering by an unknown event type")
+	}
+}
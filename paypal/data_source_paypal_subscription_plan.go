@@ -0,0 +1,54 @@
+package paypal
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"log"
+)
+
+type SubscriptionPlanDataSource struct{}
+
+func (r SubscriptionPlanDataSource) DataSource() *schema.Resource {
+	return &schema.Resource{
+		Schema:      r.Schema(),
+		ReadContext: r.Read,
+	}
+}
+
+func (r SubscriptionPlanDataSource) Schema() map[string]*schema.Schema {
+	dataSourceSchema := computedSchema(SubscriptionPlanResource{}.Schema())
+	delete(dataSourceSchema, "currency_code")
+	delete(dataSourceSchema, "request_id")
+	dataSourceSchema["id"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		Description: "The ID of the subscription plan to look up",
+	}
+	return dataSourceSchema
+}
+
+// Read - Get a subscription plan in Paypal by ID
+func (r SubscriptionPlanDataSource) Read(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*ProviderMeta).Client
+
+	planID := d.Get("id").(string)
+	subscriptionPlan, err := client.GetSubscriptionPlan(ctx, planID)
+	if isNotFound(err) {
+		return diag.Errorf("no subscription plan found with ID %q", planID)
+	}
+	if err != nil {
+		log.Printf("Error getting subscription plan %s: %s", planID, err.Error())
+		return diag.FromErr(err)
+	}
+
+	d.SetId(subscriptionPlan.ID)
+	d.Set("environment", m.(*ProviderMeta).Environment)
+	for key, value := range (SubscriptionPlanResource{}).flatten(subscriptionPlan) {
+		d.Set(key, value)
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package paypal
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/ollieparsley/terraform-provider-paypal/internal/fakepaypal"
+)
+
+// testCreateSubscriptionPlan Create a plan for a product through the resource, returning its ID
+func testCreateSubscriptionPlan(t *testing.T, meta interface{}, productID string) string {
+	t.Helper()
+
+	config := testSubscriptionPlanConfig()
+	config["product_id"] = productID
+
+	resource := SubscriptionPlanResource{}
+	d := schema.TestResourceDataRaw(t, resource.Schema(), config)
+	if diags := resource.Create(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("Expected no error creating plan. Got: %+v", diags)
+	}
+	return d.Id()
+}
+
+func TestSubscriptionPlanDataSource(t *testing.T) {
+	server := fakepaypal.NewServer()
+	defer server.Close()
+	meta := testProviderMeta(t, server)
+
+	id := testCreateSubscriptionPlan(t, meta, "PROD-1")
+
+	dataSource := SubscriptionPlanDataSource{}
+	d := schema.TestResourceDataRaw(t, dataSource.Schema(), map[string]interface{}{"id": id})
+	if diags := dataSource.Read(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("Expected no error reading plan. Got: %+v", diags)
+	}
+
+	expected := map[string]interface{}{
+		"product_id":                  "PROD-1",
+		"status":                      "ACTIVE",
+		"environment":                 "custom",
+		"billing_cycle.0.tenure_type": "regular",
+		"billing_cycle.0.frequency.0.interval_unit":            "month",
+		"billing_cycle.0.pricing_scheme.0.version":             1,
+		"billing_cycle.0.pricing_scheme.0.fixed_price.0.value": "4.99",
+		"payment_preferences.0.setup_fee.0.currency_code":      "USD",
+		"payment_preferences.0.setup_fee_failure_action":       "continue",
+		"taxes.0.percentage":                                   "20",
+	}
+	for key, value := range expected {
+		if actual := d.Get(key); actual != value {
+			t.Errorf("Expected %s to be %v. Got: %v", key, value, actual)
+		}
+	}
+
+	missing := schema.TestResourceDataRaw(t, dataSource.Schema(), map[string]interface{}{"id": "P-MISSING"})
+	if diags := dataSource.Read(context.Background(), missing, meta); !diags.HasError() {
+		t.Errorf("Expected an error looking up a missing plan")
+	}
+}
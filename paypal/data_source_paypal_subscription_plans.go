@@ -0,0 +1,103 @@
+package paypal
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"log"
+)
+
+type SubscriptionPlansDataSource struct{}
+
+func (r SubscriptionPlansDataSource) DataSource() *schema.Resource {
+	return &schema.Resource{
+		Schema:      r.Schema(),
+		ReadContext: r.Read,
+	}
+}
+
+func (r SubscriptionPlansDataSource) Schema() map[string]*schema.Schema {
+	statuses := SubscriptionPlanResource{}.statuses()
+
+	planSchema := computedSchema(SubscriptionPlanResource{}.Schema())
+	delete(planSchema, "environment")
+	delete(planSchema, "currency_code")
+	delete(planSchema, "request_id")
+	planSchema["id"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The ID of the subscription plan",
+	}
+
+	return map[string]*schema.Schema{
+		"product_id": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Only include plans for this product",
+		},
+		"status": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringInSlice(statuses, true),
+			Description:  fmt.Sprintf("Only include plans with this status. One of: %s", strings.Join(statuses, ",")),
+		},
+		"ids": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "The IDs of the matching subscription plans",
+		},
+		"plans": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The matching subscription plans",
+			Elem:        &schema.Resource{Schema: planSchema},
+		},
+	}
+}
+
+// Read - List subscription plans in Paypal. The list API only returns a summary of each plan,
+// so each plan is fetched for its billing cycles and preferences
+func (r SubscriptionPlansDataSource) Read(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*ProviderMeta).Client
+
+	productID := d.Get("product_id").(string)
+	status := d.Get("status").(string)
+
+	summaries, err := listSubscriptionPlans(ctx, client, productID)
+	if err != nil {
+		log.Printf("Error listing subscription plans: %s", err.Error())
+		return diag.FromErr(err)
+	}
+
+	ids := []string{}
+	plans := []map[string]interface{}{}
+	for _, summary := range summaries {
+		if status != "" && !strings.EqualFold(status, string(summary.Status)) {
+			continue
+		}
+
+		subscriptionPlan, err := client.GetSubscriptionPlan(ctx, summary.ID)
+		if err != nil {
+			log.Printf("Error getting subscription plan %s: %s", summary.ID, err.Error())
+			return diag.FromErr(err)
+		}
+
+		plan := SubscriptionPlanResource{}.flatten(subscriptionPlan)
+		plan["id"] = subscriptionPlan.ID
+
+		ids = append(ids, subscriptionPlan.ID)
+		plans = append(plans, plan)
+	}
+
+	d.SetId(fmt.Sprintf("plans/%s/%s", productID, strings.ToUpper(status)))
+	d.Set("ids", ids)
+	d.Set("plans", plans)
+
+	return nil
+}
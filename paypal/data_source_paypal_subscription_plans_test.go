@@ -0,0 +1,52 @@
+package paypal
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/ollieparsley/terraform-provider-paypal/internal/fakepaypal"
+)
+
+func TestSubscriptionPlansDataSourceFilters(t *testing.T) {
+	server := fakepaypal.NewServer()
+	defer server.Close()
+	meta := testProviderMeta(t, server)
+
+	active := testCreateSubscriptionPlan(t, meta, "PROD-1")
+	inactive := testCreateSubscriptionPlan(t, meta, "PROD-1")
+	other := testCreateSubscriptionPlan(t, meta, "PROD-2")
+	if err := meta.(*ProviderMeta).Client.DeactivateSubscriptionPlans(context.Background(), inactive); err != nil {
+		t.Fatalf("Expected no error deactivating plan. Got: %s", err)
+	}
+
+	dataSource := SubscriptionPlansDataSource{}
+	cases := []struct {
+		config   map[string]interface{}
+		expected []interface{}
+	}{
+		{config: map[string]interface{}{}, expected: []interface{}{active, inactive, other}},
+		{config: map[string]interface{}{"product_id": "PROD-1"}, expected: []interface{}{active, inactive}},
+		{config: map[string]interface{}{"product_id": "PROD-1", "status": "inactive"}, expected: []interface{}{inactive}},
+		{config: map[string]interface{}{"status": "CREATED"}, expected: []interface{}{}},
+	}
+
+	for _, c := range cases {
+		d := schema.TestResourceDataRaw(t, dataSource.Schema(), c.config)
+		if diags := dataSource.Read(context.Background(), d, meta); diags.HasError() {
+			t.Fatalf("Expected no error listing plans. Got: %+v", diags)
+		}
+		if differences := deep.Equal(c.expected, d.Get("ids")); len(differences) > 0 {
+			t.Errorf("Expected matching plan IDs for %v. Got differences: %+v", c.config, differences)
+		}
+	}
+
+	d := schema.TestResourceDataRaw(t, dataSource.Schema(), map[string]interface{}{"product_id": "PROD-2"})
+	if diags := dataSource.Read(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("Expected no error listing plans. Got: %+v", diags)
+	}
+	if d.Get("plans.0.id") != other || d.Get("plans.0.billing_cycle.0.pricing_scheme.0.fixed_price.0.value") != "4.99" {
+		t.Errorf("Expected plan billing cycles to be flattened. Got: %+v", d.Get("plans"))
+	}
+}
@@ -0,0 +1,93 @@
+package paypal
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"log"
+)
+
+type WebhookEventTypesDataSource struct{}
+
+func (r WebhookEventTypesDataSource) DataSource() *schema.Resource {
+	return &schema.Resource{
+		Schema:      r.Schema(),
+		ReadContext: r.Read,
+	}
+}
+
+func (r WebhookEventTypesDataSource) Schema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name_prefix": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Only include event types whose name starts with this prefix, such as BILLING.SUBSCRIPTION.",
+		},
+		"names": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "The names of the matching event types",
+		},
+		"event_types": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The matching event types",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The name of the event type",
+					},
+					"description": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "A description of the event type",
+					},
+					"status": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The status of the event type",
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read - List the event types webhooks can subscribe to - https://developer.paypal.com/docs/api/webhooks/v1/#webhooks-event-types_list
+func (r WebhookEventTypesDataSource) Read(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*ProviderMeta).Client
+
+	namePrefix := d.Get("name_prefix").(string)
+
+	response, err := client.GetWebhookEventTypes(ctx)
+	if err != nil {
+		log.Printf("Error listing webhook event types: %s", err.Error())
+		return diag.FromErr(err)
+	}
+
+	names := []string{}
+	eventTypes := []map[string]interface{}{}
+	for _, eventType := range response.EventTypes {
+		if !strings.HasPrefix(strings.ToUpper(eventType.Name), strings.ToUpper(namePrefix)) {
+			continue
+		}
+		names = append(names, eventType.Name)
+		eventTypes = append(eventTypes, map[string]interface{}{
+			"name":        eventType.Name,
+			"description": eventType.Description,
+			"status":      eventType.Status,
+		})
+	}
+
+	d.SetId("webhook-event-types/" + strings.ToUpper(namePrefix))
+	d.Set("names", names)
+	d.Set("event_types", eventTypes)
+
+	return nil
+}
@@ -0,0 +1,30 @@
+package paypal
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/ollieparsley/terraform-provider-paypal/internal/fakepaypal"
+)
+
+func TestWebhookEventTypesDataSource(t *testing.T) {
+	server := fakepaypal.NewServer()
+	defer server.Close()
+	meta := testProviderMeta(t, server)
+
+	dataSource := WebhookEventTypesDataSource{}
+	d := schema.TestResourceDataRaw(t, dataSource.Schema(), map[string]interface{}{"name_prefix": "billing.subscription."})
+	if diags := dataSource.Read(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("Expected no error listing event types. Got: %+v", diags)
+	}
+
+	expected := []interface{}{"BILLING.SUBSCRIPTION.CREATED", "BILLING.SUBSCRIPTION.CANCELLED"}
+	if differences := deep.Equal(expected, d.Get("names")); len(differences) > 0 {
+		t.Errorf("Expected event types matching the prefix. Got differences: %+v", differences)
+	}
+	if d.Get("event_types.0.description") != "A billing subscription is created." || d.Get("event_types.0.status") != "ENABLED" {
+		t.Errorf("Expected event type details to be set. Got: %+v", d.Get("event_types"))
+	}
+}
@@ -0,0 +1,93 @@
+package paypal
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"log"
+)
+
+type WebhookSignatureVerificationDataSource struct{}
+
+func (r WebhookSignatureVerificationDataSource) DataSource() *schema.Resource {
+	return &schema.Resource{
+		Schema:      r.Schema(),
+		ReadContext: r.Read,
+	}
+}
+
+func (r WebhookSignatureVerificationDataSource) Schema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"webhook_id": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "The ID of the webhook the delivery was sent to",
+		},
+		"headers": {
+			Type:        schema.TypeMap,
+			Required:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: fmt.Sprintf("The headers of the webhook delivery. Must include: %s. Names are not case sensitive", strings.Join(r.requiredHeaders(), ",")),
+		},
+		"body": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringIsJSON,
+			Description:  "The JSON body of the webhook delivery, exactly as it was received",
+		},
+		"verification_status": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The result of the verification: SUCCESS or FAILURE",
+		},
+	}
+}
+
+// Read - Verify the signature of a webhook delivery - https://developer.paypal.com/docs/api/webhooks/v1/#verify-webhook-signature_post
+func (r WebhookSignatureVerificationDataSource) Read(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*ProviderMeta).Client
+
+	webhookID := d.Get("webhook_id").(string)
+
+	// The SDK reads the signature from the headers of the delivery request
+	delivery, err := http.NewRequestWithContext(ctx, http.MethodPost, "/", strings.NewReader(d.Get("body").(string)))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	for name, value := range d.Get("headers").(map[string]interface{}) {
+		delivery.Header.Set(name, value.(string))
+	}
+	for _, name := range r.requiredHeaders() {
+		if delivery.Header.Get(name) == "" {
+			return diag.Errorf("headers: missing the %s header", name)
+		}
+	}
+
+	response, err := client.VerifyWebhookSignature(ctx, delivery, webhookID)
+	if err != nil {
+		log.Printf("Error verifying webhook signature for %s: %s", webhookID, err.Error())
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", webhookID, delivery.Header.Get("PAYPAL-TRANSMISSION-ID")))
+	d.Set("verification_status", response.VerificationStatus)
+
+	return nil
+}
+
+// requiredHeaders The PayPal headers of a webhook delivery used to verify its signature
+func (r WebhookSignatureVerificationDataSource) requiredHeaders() []string {
+	return []string{
+		"PAYPAL-AUTH-ALGO",
+		"PAYPAL-CERT-URL",
+		"PAYPAL-TRANSMISSION-ID",
+		"PAYPAL-TRANSMISSION-SIG",
+		"PAYPAL-TRANSMISSION-TIME",
+	}
+}
@@ -0,0 +1,74 @@
+package paypal
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/ollieparsley/terraform-provider-paypal/internal/fakepaypal"
+)
+
+// testWebhookDeliveryHeaders The PayPal headers of a webhook delivery with a signature
+func testWebhookDeliveryHeaders(signature string) map[string]interface{} {
+	return map[string]interface{}{
+		"paypal-auth-algo":         "SHA256withRSA",
+		"paypal-cert-url":          "https://api.paypal.com/v1/notifications/certs/CERT-1",
+		"paypal-transmission-id":   "transmission-1",
+		"paypal-transmission-sig":  signature,
+		"paypal-transmission-time": "2021-01-01T00:00:00Z",
+		"content-type":             "application/json",
+	}
+}
+
+func TestWebhookSignatureVerificationDataSource(t *testing.T) {
+	server := fakepaypal.NewServer()
+	defer server.Close()
+	meta := testProviderMeta(t, server)
+
+	webhookID := testCreateWebhook(t, meta, "https://example.com/hook", "PAYMENT.SALE.COMPLETED")
+	otherWebhookID := testCreateWebhook(t, meta, "https://example.com/other", "PAYMENT.SALE.COMPLETED")
+
+	dataSource := WebhookSignatureVerificationDataSource{}
+	cases := map[string]struct {
+		webhookID string
+		signature string
+		expected  string
+	}{
+		"signed for the webhook":     {webhookID, fakepaypal.Sign("transmission-1", webhookID), "SUCCESS"},
+		"signed for another webhook": {webhookID, fakepaypal.Sign("transmission-1", otherWebhookID), "FAILURE"},
+		"wrong webhook ID":           {otherWebhookID, fakepaypal.Sign("transmission-1", webhookID), "FAILURE"},
+	}
+
+	for name, c := range cases {
+		d := schema.TestResourceDataRaw(t, dataSource.Schema(), map[string]interface{}{
+			"webhook_id": c.webhookID,
+			"headers":    testWebhookDeliveryHeaders(c.signature),
+			"body":       `{"id":"WH-EVENT-1","event_type":"PAYMENT.SALE.COMPLETED"}`,
+		})
+		if diags := dataSource.Read(context.Background(), d, meta); diags.HasError() {
+			t.Fatalf("%s: Expected no error verifying signature. Got: %+v", name, diags)
+		}
+		if status := d.Get("verification_status"); status != c.expected {
+			t.Errorf("%s: Expected verification status %s. Got: %v", name, c.expected, status)
+		}
+	}
+}
+
+func TestWebhookSignatureVerificationDataSourceMissingHeader(t *testing.T) {
+	server := fakepaypal.NewServer()
+	defer server.Close()
+	meta := testProviderMeta(t, server)
+
+	headers := testWebhookDeliveryHeaders("signature")
+	delete(headers, "paypal-transmission-sig")
+
+	dataSource := WebhookSignatureVerificationDataSource{}
+	d := schema.TestResourceDataRaw(t, dataSource.Schema(), map[string]interface{}{
+		"webhook_id": "WH-1",
+		"headers":    headers,
+		"body":       `{}`,
+	})
+	if diags := dataSource.Read(context.Background(), d, meta); !diags.HasError() {
+		t.Errorf("Expected an error for a missing signature header")
+	}
+}
@@ -0,0 +1,119 @@
+package paypal
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/ollieparsley/terraform-provider-paypal/internal/money"
+	paypalSdk "github.com/plutov/paypal/v4"
+)
+
+// moneyResource The value and currency of a PayPal money amount
+// Doc: https://developer.paypal.com/docs/api/subscriptions/v1/#definition-money
+func moneyResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"value": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validateMoneyValue,
+				DiffSuppressFunc: diffSuppressDecimals,
+				Description:      "The amount, as a decimal string with no more decimal places than the currency allows. For example 10.00, or 1000 for JPY",
+			},
+			"currency_code": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validateCurrencyCode,
+				DiffSuppressFunc: diffSuppressDefaultCurrencyCode,
+				Description:      "The three-character ISO-4217 currency code. Must be a currency PayPal supports. Defaults to the resource's currency_code",
+			},
+		},
+	}
+}
+
+// validateMoneyValue Validate a money value is a decimal amount
+func validateMoneyValue(v interface{}, k string) (ws []string, es []error) {
+	if err := money.ValidateValue(v.(string)); err != nil {
+		es = append(es, fmt.Errorf("%s: %s", k, err))
+	}
+	return
+}
+
+// validateCurrencyCode Validate a currency code is supported by PayPal
+func validateCurrencyCode(v interface{}, k string) (ws []string, es []error) {
+	if err := money.ValidateCurrencyCode(v.(string)); err != nil {
+		es = append(es, fmt.Errorf("%s: %s", k, err))
+	}
+	return
+}
+
+// diffSuppressDecimals Compare decimal strings by amount, so 10 and 10.00 are the same
+func diffSuppressDecimals(k, old, new string, d *schema.ResourceData) bool {
+	return money.Equal(old, new)
+}
+
+// diffSuppressDefaultCurrencyCode An unset currency code is the same as the resource's currency_code
+func diffSuppressDefaultCurrencyCode(k, old, new string, d *schema.ResourceData) bool {
+	defaultCurrencyCode, _ := d.Get("currency_code").(string)
+	return new == "" && old == defaultCurrencyCode
+}
+
+// moneyCurrencyCode The currency of the money block at key, falling back to the resource's currency_code.
+// Returns false when the currency is only known at apply time
+func moneyCurrencyCode(d *schema.ResourceDiff, key string) (string, bool) {
+	if !d.NewValueKnown(key + ".currency_code") {
+		return "", false
+	}
+	if currencyCode := d.Get(key + ".currency_code").(string); currencyCode != "" {
+		return currencyCode, true
+	}
+	if !d.NewValueKnown("currency_code") {
+		return "", false
+	}
+	return d.Get("currency_code").(string), true
+}
+
+// validateMoneyDiff Check every money block has a currency, the same currency as each other and the
+// resource's currency_code, and no more decimal places than that currency allows. Blocks with values
+// only known at apply time are skipped
+func validateMoneyDiff(d *schema.ResourceDiff, keys []string) error {
+	planCurrencyCode, planCurrencyKey := "", ""
+	if d.NewValueKnown("currency_code") {
+		planCurrencyCode, planCurrencyKey = d.Get("currency_code").(string), "currency_code"
+	}
+	for _, key := range keys {
+		currencyCode, known := moneyCurrencyCode(d, key)
+		if !known {
+			continue
+		}
+		if currencyCode == "" {
+			return fmt.Errorf("%s: currency_code must be set, either in the block or on the resource", key)
+		}
+
+		if planCurrencyCode == "" {
+			planCurrencyCode, planCurrencyKey = currencyCode, key
+		} else if currencyCode != planCurrencyCode {
+			return fmt.Errorf("%s: currency %s does not match %s used by %s. PayPal requires a single currency", key, currencyCode, planCurrencyCode, planCurrencyKey)
+		}
+
+		if !d.NewValueKnown(key + ".value") {
+			continue
+		}
+		if err := money.Validate(d.Get(key+".value").(string), currencyCode); err != nil {
+			return fmt.Errorf("%s: %s", key, err)
+		}
+	}
+	return nil
+}
+
+// moneyFromResourceData Build a PayPal money amount from a money block, falling back to the resource's currency_code
+func moneyFromResourceData(d *schema.ResourceData, data map[string]interface{}) *paypalSdk.Money {
+	currencyCode := data["currency_code"].(string)
+	if currencyCode == "" {
+		currencyCode = d.Get("currency_code").(string)
+	}
+	return &paypalSdk.Money{
+		Currency: currencyCode,
+		Value:    data["value"].(string),
+	}
+}
@@ -0,0 +1,14 @@
+package paypal
+
+import (
+	"testing"
+)
+
+func TestDiffSuppressDecimals(t *testing.T) {
+	if !diffSuppressDecimals("value", "10.00", "10", nil) {
+		t.Errorf("Expected 10.00 and 10 to be the same amount")
+	}
+	if diffSuppressDecimals("value", "19.99", "19.990000000000000001", nil) {
+		t.Errorf("Expected amounts beyond float precision to differ")
+	}
+}
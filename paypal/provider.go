@@ -2,23 +2,37 @@ package paypal
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"log"
+	"strings"
+	"time"
 
-	"github.com/hashicorp/terraform/helper/schema"
-	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	paypalSdk "github.com/plutov/paypal/v4"
 )
 
-// Provider returns a terraform.ResourceProvider.
-func Provider() terraform.ResourceProvider {
+// Provider returns a *schema.Provider.
+func Provider() *schema.Provider {
 
 	// Internal mapping of resources to ensure matching interface
 	internalResourceMapping := map[string]TerraformResource{
 		"paypal_notification_webhook": WebhookResource{},
 		"paypal_catalog_product":      CatalogProductResource{},
 		"paypal_subscription_plan":    SubscriptionPlanResource{},
+		"paypal_webhook_simulation":   WebhookSimulationResource{},
+	}
+
+	// Internal mapping of data sources to ensure matching interface
+	internalDataSourceMapping := map[string]TerraformDataSource{
+		"paypal_catalog_product":                CatalogProductDataSource{},
+		"paypal_catalog_products":               CatalogProductsDataSource{},
+		"paypal_subscription_plan":              SubscriptionPlanDataSource{},
+		"paypal_subscription_plans":             SubscriptionPlansDataSource{},
+		"paypal_notification_webhooks":          NotificationWebhooksDataSource{},
+		"paypal_webhook_event_types":            WebhookEventTypesDataSource{},
+		"paypal_webhook_signature_verification": WebhookSignatureVerificationDataSource{},
 	}
 
 	// Map to the terraform resource from our internal representation
@@ -26,61 +40,218 @@ func Provider() terraform.ResourceProvider {
 	for resourceName, resource := range internalResourceMapping {
 		providerResourceMap[resourceName] = resource.Resource()
 	}
+	providerDataSourceMap := map[string]*schema.Resource{}
+	for dataSourceName, dataSource := range internalDataSourceMapping {
+		providerDataSourceMap[dataSourceName] = dataSource.DataSource()
+	}
 
 	return &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"client_id": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Sensitive:   true,
-				Description: "Your PayPal OAuth Client ID. You can get this from your developer dashboard https://developer.paypal.com/developer/applications",
-				DefaultFunc: schema.EnvDefaultFunc("PAYPAL_CLIENT_ID", nil),
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{"access_token"},
+				Description:   "Your PayPal OAuth Client ID. You can get this from your developer dashboard https://developer.paypal.com/developer/applications. Required unless access_token is set",
+				DefaultFunc:   schema.EnvDefaultFunc("PAYPAL_CLIENT_ID", nil),
 			},
 			"client_secret": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Sensitive:   true,
-				Description: "Your PayPal OAuth Client Secret. You can get this from your developer dashboard https://developer.paypal.com/developer/applications",
-				DefaultFunc: schema.EnvDefaultFunc("PAYPAL_CLIENT_SECRET", nil),
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{"access_token"},
+				Description:   "Your PayPal OAuth Client Secret. You can get this from your developer dashboard https://developer.paypal.com/developer/applications. Required unless access_token is set",
+				DefaultFunc:   schema.EnvDefaultFunc("PAYPAL_CLIENT_SECRET", nil),
+			},
+			"access_token": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{"client_id", "client_secret"},
+				Description:   "A PayPal OAuth access token to use instead of client_id and client_secret. It is used as given and never refreshed, so it must stay valid for the whole run. Conflicts with client_id and client_secret",
+				DefaultFunc:   schema.EnvDefaultFunc("PAYPAL_ACCESS_TOKEN", nil),
 			},
 			"base_url": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"environment"},
+				Description:   fmt.Sprintf("The base API url. Default is production %s, but you can set it to the sandbox URL. Conflicts with environment", paypalSdk.APIBaseLive),
+				DefaultFunc:   schema.EnvDefaultFunc("PAYPAL_BASE_URL", nil),
+			},
+			"environment": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"base_url"},
+				ValidateFunc:  validation.StringInSlice(environments(), false),
+				Description:   fmt.Sprintf("The PayPal environment to manage. One of: %s. Default is live. Conflicts with base_url", strings.Join(environments(), ",")),
+				DefaultFunc:   schema.EnvDefaultFunc("PAYPAL_ENVIRONMENT", nil),
+			},
+			"partner_attribution_id": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				Default:     paypalSdk.APIBaseLive,
-				Description: fmt.Sprintf("The base API url. Default is production %s, but you can set it to the sandbox URL", paypalSdk.APIBaseLive),
-				DefaultFunc: schema.EnvDefaultFunc("PAYPAL_BASE_URL", paypalSdk.APIBaseLive),
+				Description: "Your PayPal partner attribution ID (BN code), sent as the PayPal-Partner-Attribution-Id header on every request",
+				DefaultFunc: schema.EnvDefaultFunc("PAYPAL_PARTNER_ATTRIBUTION_ID", nil),
+			},
+			"act_as_merchant_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"act_as_email"},
+				Description:   "The payer ID of a merchant to manage on behalf of, sent in a PayPal-Auth-Assertion header on every request. Requires partner credentials with the merchant's consent. Conflicts with act_as_email",
+				DefaultFunc:   schema.EnvDefaultFunc("PAYPAL_ACT_AS_MERCHANT_ID", nil),
+			},
+			"act_as_email": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"act_as_merchant_id"},
+				Description:   "The email address of a merchant to manage on behalf of, sent in a PayPal-Auth-Assertion header on every request. Requires partner credentials with the merchant's consent. Conflicts with act_as_merchant_id",
+				DefaultFunc:   schema.EnvDefaultFunc("PAYPAL_ACT_AS_EMAIL", nil),
+			},
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      3,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "The maximum number of times a request is retried when PayPal responds with a rate limit (429) or server error (5xx). Creates are sent with a PayPal-Request-Id, so are also retried when no response is received without creating duplicates. Set to 0 to disable retries",
+			},
+			"retry_min_wait": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "1s",
+				ValidateFunc: validateDuration,
+				Description:  "How long to wait before the first retry, doubling on each further retry. A Retry-After header sent by PayPal takes precedence",
+			},
+			"retry_max_wait": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "30s",
+				ValidateFunc: validateDuration,
+				Description:  "The longest time to wait between retries, including any Retry-After header sent by PayPal",
 			},
 		},
-		ResourcesMap:  providerResourceMap,
-		ConfigureFunc: providerConfigure,
+		ResourcesMap:         providerResourceMap,
+		DataSourcesMap:       providerDataSourceMap,
+		ConfigureContextFunc: providerConfigure,
 	}
 }
 
-func providerConfigure(d *schema.ResourceData) (interface{}, error) {
+func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+	// Durations have already been validated
+	retryMinWait, _ := time.ParseDuration(d.Get("retry_min_wait").(string))
+	retryMaxWait, _ := time.ParseDuration(d.Get("retry_max_wait").(string))
+
+	// Pick the base URL from the environment unless one is given explicitly
+	baseURL := d.Get("base_url").(string)
+	if baseURL == "" {
+		environment := d.Get("environment").(string)
+		if environment == "" {
+			environment = environmentLive
+		}
+		baseURL = environmentBaseURLs[environment]
+	}
+
 	config := Config{
 		ClientID:     d.Get("client_id").(string),
 		ClientSecret: d.Get("client_secret").(string),
-		BaseURL:      d.Get("base_url").(string),
-	}
+		AccessToken:  d.Get("access_token").(string),
+		BaseURL:      baseURL,
+		MaxRetries:   d.Get("max_retries").(int),
+		RetryMinWait: retryMinWait,
+		RetryMaxWait: retryMaxWait,
 
-	if config.ClientID == "" {
-		return nil, errors.New("a PayPal client_id is required")
-	}
-	if config.ClientSecret == "" {
-		return nil, errors.New("a PayPal client_secret is required")
+		PartnerAttributionID: d.Get("partner_attribution_id").(string),
+		ActAsMerchantID:      d.Get("act_as_merchant_id").(string),
+		ActAsEmail:           d.Get("act_as_email").(string),
 	}
 
-	log.Println("[INFO] Initializing Paypal client with client credentials")
+	if config.AccessToken != "" {
+		// Credentials can also come from the environment, so are checked here as well as by ConflictsWith
+		if config.ClientID != "" || config.ClientSecret != "" {
+			return nil, diag.Errorf("access_token cannot be used with client_id or client_secret, set one or the other")
+		}
+		if config.ActAsMerchantID != "" || config.ActAsEmail != "" {
+			return nil, diag.Errorf("act_as_merchant_id and act_as_email need client_id and client_secret, as the PayPal-Auth-Assertion names the client_id")
+		}
+	} else {
+		if config.ClientID == "" {
+			return nil, diag.Errorf("a PayPal client_id is required, or an access_token")
+		}
+		if config.ClientSecret == "" {
+			return nil, diag.Errorf("a PayPal client_secret is required, or an access_token")
+		}
+	}
 
 	client, clientErr := config.Client()
 	if clientErr != nil {
-		return client, clientErr
+		return nil, diag.FromErr(clientErr)
 	}
 
-	_, accessTokenErr := client.GetAccessToken(context.Background())
-	if accessTokenErr != nil {
-		return client, accessTokenErr
+	// A given access token is used as is, otherwise exchange the client credentials for one
+	if config.AccessToken != "" {
+		log.Println("[INFO] Initializing Paypal client with an access token")
+	} else {
+		log.Println("[INFO] Initializing Paypal client with client credentials")
+
+		_, accessTokenErr := client.GetAccessToken(ctx)
+		if accessTokenErr != nil {
+			return nil, diag.FromErr(accessTokenErr)
+		}
+	}
+
+	return &ProviderMeta{
+		Client:      &sdkClient{Client: client},
+		Environment: environmentForBaseURL(config.BaseURL),
+	}, nil
+}
+
+// PayPal environments that can be selected with the environment argument,
+// and the name given to any other base URL
+const (
+	environmentSandbox = "sandbox"
+	environmentLive    = "live"
+	environmentCustom  = "custom"
+)
+
+// environmentBaseURLs The API base URL of each PayPal environment
+var environmentBaseURLs = map[string]string{
+	environmentSandbox: paypalSdk.APIBaseSandBox,
+	environmentLive:    paypalSdk.APIBaseLive,
+}
+
+// environments List of acceptable environments
+func environments() []string {
+	return []string{
+		environmentSandbox,
+		environmentLive,
 	}
+}
 
-	return client, clientErr
+// environmentForBaseURL Name the environment a base URL belongs to
+func environmentForBaseURL(baseURL string) string {
+	for environment, environmentBaseURL := range environmentBaseURLs {
+		if strings.TrimRight(baseURL, "/") == environmentBaseURL {
+			return environment
+		}
+	}
+	return environmentCustom
+}
+
+// customizeDiffEnvironment Show which environment a new object will be created in
+func customizeDiffEnvironment(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	meta, ok := m.(*ProviderMeta)
+	if !ok || d.Id() != "" {
+		return nil
+	}
+	return d.SetNew("environment", meta.Environment)
+}
+
+// validateDuration Validate a positive duration string such as "500ms" or "30s"
+func validateDuration(v interface{}, k string) (warnings []string, errors []error) {
+	duration, err := time.ParseDuration(v.(string))
+	if err != nil {
+		return nil, []error{fmt.Errorf("%s must be a duration such as \"30s\": %s", k, err)}
+	}
+	if duration <= 0 {
+		return nil, []error{fmt.Errorf("%s must be a positive duration", k)}
+	}
+	return nil, nil
 }
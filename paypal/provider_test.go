@@ -0,0 +1,202 @@
+package paypal
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/ollieparsley/terraform-provider-paypal/internal/fakepaypal"
+	paypalSdk "github.com/plutov/paypal/v4"
+)
+
+// testProviderMeta Configure the provider against a fake PayPal API and return its meta
+func testProviderMeta(t *testing.T, server *fakepaypal.Server) interface{} {
+	t.Helper()
+
+	provider := Provider()
+	diags := provider.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"client_id":     fakepaypal.ClientID,
+		"client_secret": fakepaypal.ClientSecret,
+		"base_url":      server.URL,
+	}))
+	if diags.HasError() {
+		t.Fatalf("Expected provider to configure against the fake API. Got: %+v", diags)
+	}
+
+	return provider.Meta()
+}
+
+func TestProvider(t *testing.T) {
+	if err := Provider().InternalValidate(); err != nil {
+		t.Fatalf("Expected valid provider. Got: %s", err)
+	}
+}
+
+func TestProviderConfigureInvalidCredentials(t *testing.T) {
+	server := fakepaypal.NewServer()
+	defer server.Close()
+
+	provider := Provider()
+	diags := provider.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"client_id":     fakepaypal.ClientID,
+		"client_secret": "wrong",
+		"base_url":      server.URL,
+	}))
+	if !diags.HasError() {
+		t.Errorf("Expected an error configuring the provider with invalid credentials")
+	}
+}
+
+func TestValidateDuration(t *testing.T) {
+	for _, valid := range []string{"500ms", "1s", "2m"} {
+		if _, errs := validateDuration(valid, "retry_min_wait"); len(errs) > 0 {
+			t.Errorf("Expected %q to be valid. Got: %v", valid, errs)
+		}
+	}
+	for _, invalid := range []string{"", "10", "-1s", "0s"} {
+		if _, errs := validateDuration(invalid, "retry_min_wait"); len(errs) == 0 {
+			t.Errorf("Expected %q to be invalid", invalid)
+		}
+	}
+}
+
+func TestProviderConfigureEnvironmentConflictsWithBaseURL(t *testing.T) {
+	provider := Provider()
+	diags := provider.Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+		"client_id":     fakepaypal.ClientID,
+		"client_secret": fakepaypal.ClientSecret,
+		"base_url":      "https://example.com",
+		"environment":   "sandbox",
+	}))
+	if !diags.HasError() {
+		t.Errorf("Expected environment and base_url to conflict")
+	}
+}
+
+func TestProviderActAsConflicts(t *testing.T) {
+	provider := Provider()
+	diags := provider.Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+		"client_id":          fakepaypal.ClientID,
+		"client_secret":      fakepaypal.ClientSecret,
+		"act_as_merchant_id": "MERCHANT1",
+		"act_as_email":       "seller@example.com",
+	}))
+	if !diags.HasError() {
+		t.Errorf("Expected act_as_merchant_id and act_as_email to conflict")
+	}
+}
+
+func TestProviderConfigureRecordsEnvironment(t *testing.T) {
+	server := fakepaypal.NewServer()
+	defer server.Close()
+
+	meta := testProviderMeta(t, server).(*ProviderMeta)
+	if meta.Environment != "custom" {
+		t.Errorf("Expected a custom environment for the fake API. Got: %s", meta.Environment)
+	}
+
+	resource := CatalogProductResource{}
+	d := schema.TestResourceDataRaw(t, resource.Schema(), map[string]interface{}{
+		"name":      "Product",
+		"image_url": "https://example.com/image.png",
+		"home_url":  "https://example.com",
+		"type":      "digital",
+	})
+	if diags := resource.Create(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("Expected no error creating product. Got: %+v", diags)
+	}
+	if d.Get("environment") != "custom" {
+		t.Errorf("Expected the environment to be recorded on the product. Got: %v", d.Get("environment"))
+	}
+}
+
+func TestEnvironmentForBaseURL(t *testing.T) {
+	cases := map[string]string{
+		paypalSdk.APIBaseSandBox:       "sandbox",
+		paypalSdk.APIBaseLive:          "live",
+		paypalSdk.APIBaseLive + "/":    "live",
+		"http://127.0.0.1:8080":        "custom",
+		"https://api.example.com/live": "custom",
+	}
+	for baseURL, expected := range cases {
+		if actual := environmentForBaseURL(baseURL); actual != expected {
+			t.Errorf("Expected %s to be %s. Got: %s", baseURL, expected, actual)
+		}
+	}
+}
+
+func TestProviderConfigurePartnerAttributionID(t *testing.T) {
+	server := fakepaypal.NewServer()
+	defer server.Close()
+
+	provider := Provider()
+	diags := provider.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"client_id":              fakepaypal.ClientID,
+		"client_secret":          fakepaypal.ClientSecret,
+		"base_url":               server.URL,
+		"partner_attribution_id": "EXAMPLE_BN_CODE",
+	}))
+	if diags.HasError() {
+		t.Fatalf("Expected provider to configure against the fake API. Got: %+v", diags)
+	}
+	if _, err := provider.Meta().(*ProviderMeta).Client.ListWebhooks(context.Background(), ""); err != nil {
+		t.Fatalf("Expected no error listing webhooks. Got: %s", err)
+	}
+
+	requests := server.Requests()
+	if len(requests) != 2 {
+		t.Fatalf("Expected a token request and a list request. Got: %d", len(requests))
+	}
+	for _, request := range requests {
+		if actual := request.Header.Get("PayPal-Partner-Attribution-Id"); actual != "EXAMPLE_BN_CODE" {
+			t.Errorf("Expected %s %s to be attributed to the partner. Got: %q", request.Method, request.Path, actual)
+		}
+	}
+}
+
+func TestProviderConfigureAccessToken(t *testing.T) {
+	server := fakepaypal.NewServer()
+	defer server.Close()
+
+	provider := Provider()
+	diags := provider.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"access_token": fakepaypal.AccessToken,
+		"base_url":     server.URL,
+	}))
+	if diags.HasError() {
+		t.Fatalf("Expected provider to configure with an access token. Got: %+v", diags)
+	}
+	if _, err := provider.Meta().(*ProviderMeta).Client.ListWebhooks(context.Background(), ""); err != nil {
+		t.Fatalf("Expected no error listing webhooks with the access token. Got: %s", err)
+	}
+
+	// The token is used without exchanging client credentials for another
+	for _, request := range server.Requests() {
+		if request.Path == "/v1/oauth2/token" {
+			t.Errorf("Expected no OAuth token request when an access token is given")
+		}
+	}
+}
+
+func TestProviderConfigureAccessTokenConflicts(t *testing.T) {
+	provider := Provider()
+	diags := provider.Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+		"access_token":  fakepaypal.AccessToken,
+		"client_secret": fakepaypal.ClientSecret,
+	}))
+	if !diags.HasError() {
+		t.Errorf("Expected access_token and client_secret to conflict")
+	}
+
+	for _, config := range []map[string]interface{}{
+		{"base_url": "https://example.com"},
+		{"base_url": "https://example.com", "client_id": fakepaypal.ClientID},
+		{"base_url": "https://example.com", "access_token": fakepaypal.AccessToken, "act_as_merchant_id": "MERCHANT1"},
+	} {
+		diags := Provider().Configure(context.Background(), terraform.NewResourceConfigRaw(config))
+		if !diags.HasError() {
+			t.Errorf("Expected an error configuring the provider with %+v", config)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package paypal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	paypalSdk "github.com/plutov/paypal/v4"
+)
+
+// requestIDHeader PayPal returns the original response for a repeated request ID instead of handling the request again
+// Doc: https://developer.paypal.com/docs/api/reference/api-requests/#http-request-headers
+const requestIDHeader = "PayPal-Request-Id"
+
+// requestIDPaths The create endpoints sent a request ID. Other POSTs made while creating, such as refreshing
+// the access token, are sent without one
+var requestIDPaths = []string{
+	"/v1/catalogs/products",
+	"/v1/notifications/webhooks",
+	"/v1/billing/plans",
+}
+
+// pendingIDPrefix Marks the ID of a resource whose create may have been handled by PayPal without a response
+const pendingIDPrefix = "pending-create:"
+
+// requestIDSchema The computed request_id attribute of resources created with a request ID
+func requestIDSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The PayPal-Request-Id the object was created with. If a create gets no response, the next apply resends it to pick up the object PayPal created",
+	}
+}
+
+// requestIDKey The context key holding the request ID to send
+type requestIDKey struct{}
+
+// withRequestID A context that sends the request ID with create requests
+func withRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// requestIDFromContext The request ID to send with requests made with the context, if any
+func requestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// createRequestID The request ID to create an object with, saved in request_id. A create replacing one that got
+// no response for the same object reuses its request ID, so PayPal returns the object it created then
+func createRequestID(d *schema.ResourceData, m interface{}, object interface{}) (string, error) {
+	key, err := pendingCreateKey(object)
+	if err != nil {
+		return "", err
+	}
+
+	requestID := m.(*ProviderMeta).pendingCreates.take(key)
+	if requestID != "" {
+		log.Printf("[INFO] Resending the create that got no response with request ID %s", requestID)
+	} else if requestID, err = uuid.GenerateUUID(); err != nil {
+		return "", err
+	}
+
+	d.Set("request_id", requestID)
+	return requestID, nil
+}
+
+// createFailed Report a failed create. When PayPal may have created the object anyway, the resource is kept in
+// state with a pending ID, so its request ID is kept for the create that replaces it
+func createFailed(d *schema.ResourceData, err error) diag.Diagnostics {
+	if mayHaveCreated(err) {
+		d.SetId(pendingIDPrefix + d.Get("request_id").(string))
+	}
+	return diag.FromErr(err)
+}
+
+// isPendingID Whether a resource ID marks a create that got no response
+func isPendingID(id string) bool {
+	return strings.HasPrefix(id, pendingIDPrefix)
+}
+
+// deletePendingCreate Remove a resource whose create got no response from state, leaving its request ID for
+// a create of the same object in this apply to send again
+func deletePendingCreate(d *schema.ResourceData, m interface{}, object interface{}) diag.Diagnostics {
+	key, err := pendingCreateKey(object)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	requestID := d.Get("request_id").(string)
+	m.(*ProviderMeta).pendingCreates.add(key, requestID)
+	d.SetId("")
+
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  "Create was not confirmed by PayPal",
+		Detail:   fmt.Sprintf("The previous create got no response, so PayPal may have created the object. A replacement created in this apply resends request ID %s to pick it up; otherwise check PayPal for an object created with that request ID", requestID),
+	}}
+}
+
+// mayHaveCreated Whether PayPal may have handled a create that failed: it got no response or a server error
+func mayHaveCreated(err error) bool {
+	var errorResponse *paypalSdk.ErrorResponse
+	if errors.As(err, &errorResponse) && errorResponse.Response != nil {
+		return errorResponse.Response.StatusCode >= http.StatusInternalServerError
+	}
+	return true
+}
+
+// pendingCreateKey Identifies the object a create sends, so a create is only resent for the same object
+func pendingCreateKey(object interface{}) (string, error) {
+	body, err := json.Marshal(object)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%T:%s", object, body), nil
+}
+
+// pendingCreates The request IDs of creates that got no response, by the object they create
+type pendingCreates struct {
+	mu         sync.Mutex
+	requestIDs map[string][]string
+}
+
+// add Keep a request ID for the next create of the object
+func (p *pendingCreates) add(key, requestID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.requestIDs == nil {
+		p.requestIDs = map[string][]string{}
+	}
+	p.requestIDs[key] = append(p.requestIDs[key], requestID)
+}
+
+// take Remove and return a request ID kept for the object, if any
+func (p *pendingCreates) take(key string) string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	requestIDs := p.requestIDs[key]
+	if len(requestIDs) == 0 {
+		return ""
+	}
+	p.requestIDs[key] = requestIDs[1:]
+	return requestIDs[0]
+}
+
+// requestIDTransport Adds the PayPal-Request-Id header to create requests made with a request ID context
+type requestIDTransport struct {
+	transport http.RoundTripper
+}
+
+// RoundTrip Send the request with its request ID
+func (t *requestIDTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	requestID := requestIDFromContext(req.Context())
+	if requestID == "" || !isCreateRequest(req) || req.Header.Get(requestIDHeader) != "" {
+		return t.transport.RoundTrip(req)
+	}
+
+	req = req.Clone(req.Context())
+	req.Header.Set(requestIDHeader, requestID)
+	return t.transport.RoundTrip(req)
+}
+
+// isCreateRequest Whether a request is a POST to one of the create endpoints
+func isCreateRequest(req *http.Request) bool {
+	if req.Method != http.MethodPost {
+		return false
+	}
+	path := strings.TrimSuffix(req.URL.Path, "/")
+	for _, createPath := range requestIDPaths {
+		if strings.HasSuffix(path, createPath) {
+			return true
+		}
+	}
+	return false
+}
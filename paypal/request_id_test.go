@@ -0,0 +1,248 @@
+package paypal
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/ollieparsley/terraform-provider-paypal/internal/fakepaypal"
+)
+
+// recordingTransport Records the requests it is given and responds with no content
+type recordingTransport struct {
+	requests []*http.Request
+}
+
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.requests = append(t.requests, req)
+	return &http.Response{StatusCode: http.StatusNoContent, Body: http.NoBody, Request: req}, nil
+}
+
+func TestRequestIDTransport(t *testing.T) {
+	recorder := &recordingTransport{}
+	client := &http.Client{Transport: &requestIDTransport{transport: recorder}}
+
+	requestID := "request-1"
+	ctx := withRequestID(context.Background(), requestID)
+
+	// Only creates are sent the request ID, not the token refresh or reads made with the same context
+	for _, request := range []struct{ method, path string }{
+		{http.MethodPost, "/v1/catalogs/products"},
+		{http.MethodPost, "/v1/oauth2/token"},
+		{http.MethodGet, "/v1/catalogs/products"},
+	} {
+		req, _ := http.NewRequestWithContext(ctx, request.method, "https://example.com"+request.path, nil)
+		if _, err := client.Do(req); err != nil {
+			t.Fatalf("Expected no error sending %s %s. Got: %s", request.method, request.path, err)
+		}
+	}
+	req, _ := http.NewRequest(http.MethodPost, "https://example.com/v1/catalogs/products", nil)
+	if _, err := client.Do(req); err != nil {
+		t.Fatalf("Expected no error sending POST. Got: %s", err)
+	}
+
+	expected := []string{requestID, "", "", ""}
+	for i, req := range recorder.requests {
+		if actual := req.Header.Get(requestIDHeader); actual != expected[i] {
+			t.Errorf("Expected %s %s to have request ID %q. Got: %q", req.Method, req.URL.Path, expected[i], actual)
+		}
+	}
+}
+
+func TestCreateRetriedAfterTimeoutReturnsSameObject(t *testing.T) {
+	server := fakepaypal.NewServer()
+	defer server.Close()
+
+	provider := Provider()
+	diags := provider.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"client_id":      fakepaypal.ClientID,
+		"client_secret":  fakepaypal.ClientSecret,
+		"base_url":       server.URL,
+		"retry_min_wait": "1ms",
+	}))
+	if diags.HasError() {
+		t.Fatalf("Expected provider to configure against the fake API. Got: %+v", diags)
+	}
+	meta := provider.Meta()
+
+	testCases := map[string]struct {
+		resource TerraformResource
+		config   map[string]interface{}
+		get      func(id string) map[string]interface{}
+	}{
+		"product": {
+			resource: CatalogProductResource{},
+			config: map[string]interface{}{
+				"name":      "Product",
+				"image_url": "https://example.com/image.png",
+				"home_url":  "https://example.com",
+				"type":      "digital",
+			},
+			get: server.Product,
+		},
+		"subscription plan": {
+			resource: SubscriptionPlanResource{},
+			config:   testSubscriptionPlanConfig(),
+			get:      server.Plan,
+		},
+		"webhook": {
+			resource: WebhookResource{},
+			config: map[string]interface{}{
+				"url":         "https://example.com/hook",
+				"event_types": []interface{}{"PAYMENT.SALE.COMPLETED"},
+			},
+			get: server.Webhook,
+		},
+	}
+	for name, testCase := range testCases {
+		// PayPal creates the object but the response is lost, so the create is retried
+		server.FailNextAfterHandling(1, http.StatusGatewayTimeout)
+		before := len(server.Requests())
+
+		d := schema.TestResourceDataRaw(t, testCase.resource.Schema(), testCase.config)
+		if diags := testCase.resource.Create(context.Background(), d, meta); diags.HasError() {
+			t.Fatalf("%s: Expected no error creating after a retry. Got: %+v", name, diags)
+		}
+		if testCase.get(d.Id()) == nil {
+			t.Fatalf("%s: Expected the created object to be stored. Got ID: %s", name, d.Id())
+		}
+
+		var requestIDs []string
+		for _, request := range server.Requests()[before:] {
+			if request.Method == http.MethodPost {
+				requestIDs = append(requestIDs, request.Header.Get(requestIDHeader))
+			}
+		}
+		if len(requestIDs) != 2 || requestIDs[0] == "" || requestIDs[0] != requestIDs[1] {
+			t.Errorf("%s: Expected the create to be retried with the same request ID. Got: %q", name, requestIDs)
+		}
+	}
+
+	// Only one of each object was created, so listing finds no duplicates
+	products, err := listProducts(context.Background(), meta.(*ProviderMeta).Client)
+	if err != nil || len(products) != 1 {
+		t.Errorf("Expected a single product. Got %d products and error: %v", len(products), err)
+	}
+	plans, err := listSubscriptionPlans(context.Background(), meta.(*ProviderMeta).Client, "")
+	if err != nil || len(plans) != 1 {
+		t.Errorf("Expected a single subscription plan. Got %d plans and error: %v", len(plans), err)
+	}
+	webhooks, err := meta.(*ProviderMeta).Client.ListWebhooks(context.Background(), "")
+	if err != nil || len(webhooks.Webhooks) != 1 {
+		t.Errorf("Expected a single webhook. Got: %+v and error: %v", webhooks, err)
+	}
+}
+
+func TestCreateWithoutResponseIsResentOnReplacement(t *testing.T) {
+	server := fakepaypal.NewServer()
+	defer server.Close()
+
+	provider := Provider()
+	diags := provider.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"client_id":     fakepaypal.ClientID,
+		"client_secret": fakepaypal.ClientSecret,
+		"base_url":      server.URL,
+		"max_retries":   0,
+	}))
+	if diags.HasError() {
+		t.Fatalf("Expected provider to configure against the fake API. Got: %+v", diags)
+	}
+	meta := provider.Meta()
+
+	testCases := map[string]struct {
+		resource TerraformResource
+		config   map[string]interface{}
+		get      func(id string) map[string]interface{}
+	}{
+		"product": {
+			resource: CatalogProductResource{},
+			config: map[string]interface{}{
+				"name":      "Product",
+				"image_url": "https://example.com/image.png",
+				"home_url":  "https://example.com",
+				"type":      "digital",
+			},
+			get: server.Product,
+		},
+		"subscription plan": {
+			resource: SubscriptionPlanResource{},
+			config:   testSubscriptionPlanConfig(),
+			get:      server.Plan,
+		},
+		"webhook": {
+			resource: WebhookResource{},
+			config: map[string]interface{}{
+				"url":         "https://example.com/hook",
+				"event_types": []interface{}{"PAYMENT.SALE.COMPLETED"},
+			},
+			get: server.Webhook,
+		},
+	}
+	for name, testCase := range testCases {
+		// PayPal creates the object but the response is lost and not retried, so the create fails
+		server.FailNextAfterHandling(1, http.StatusGatewayTimeout)
+		d := schema.TestResourceDataRaw(t, testCase.resource.Schema(), testCase.config)
+		if diags := testCase.resource.Create(context.Background(), d, meta); !diags.HasError() {
+			t.Fatalf("%s: Expected an error creating without a response", name)
+		}
+		requestID := d.Get("request_id").(string)
+		if !isPendingID(d.Id()) || requestID == "" {
+			t.Fatalf("%s: Expected a pending ID and request ID to be kept in state. Got ID %q and request ID %q", name, d.Id(), requestID)
+		}
+
+		// Terraform refreshes the tainted resource, then replaces it on the next apply
+		tainted := testCase.resource.Resource().Data(d.State())
+		if diags := testCase.resource.Read(context.Background(), tainted, meta); diags.HasError() || !isPendingID(tainted.Id()) {
+			t.Fatalf("%s: Expected a pending create to be kept when read. Got ID %q and: %+v", name, tainted.Id(), diags)
+		}
+		if diags := testCase.resource.Delete(context.Background(), tainted, meta); diags.HasError() || tainted.Id() != "" {
+			t.Fatalf("%s: Expected a pending create to be removed from state. Got ID %q and: %+v", name, tainted.Id(), diags)
+		}
+
+		replacement := schema.TestResourceDataRaw(t, testCase.resource.Schema(), testCase.config)
+		if diags := testCase.resource.Create(context.Background(), replacement, meta); diags.HasError() {
+			t.Fatalf("%s: Expected no error creating the replacement. Got: %+v", name, diags)
+		}
+		if replacement.Get("request_id") != requestID || testCase.get(replacement.Id()) == nil {
+			t.Errorf("%s: Expected the replacement to resend request ID %q. Got ID %q and request ID %q", name, requestID, replacement.Id(), replacement.Get("request_id"))
+		}
+	}
+
+	// The replacements picked up the objects created without a response, so there are no duplicates
+	products, err := listProducts(context.Background(), meta.(*ProviderMeta).Client)
+	if err != nil || len(products) != 1 {
+		t.Errorf("Expected a single product. Got %d products and error: %v", len(products), err)
+	}
+	plans, err := listSubscriptionPlans(context.Background(), meta.(*ProviderMeta).Client, "")
+	if err != nil || len(plans) != 1 {
+		t.Errorf("Expected a single subscription plan. Got %d plans and error: %v", len(plans), err)
+	}
+	webhooks, err := meta.(*ProviderMeta).Client.ListWebhooks(context.Background(), "")
+	if err != nil || len(webhooks.Webhooks) != 1 {
+		t.Errorf("Expected a single webhook. Got: %+v and error: %v", webhooks, err)
+	}
+}
+
+func TestRejectedCreateIsNotKept(t *testing.T) {
+	server := fakepaypal.NewServer()
+	defer server.Close()
+	meta := testProviderMeta(t, server)
+
+	// PayPal responded that it did not handle the create, so there is nothing to pick up later
+	server.FailNext(1, http.StatusBadRequest, "")
+	resource := CatalogProductResource{}
+	d := schema.TestResourceDataRaw(t, resource.Schema(), map[string]interface{}{
+		"name":      "Product",
+		"image_url": "https://example.com/image.png",
+		"home_url":  "https://example.com",
+		"type":      "digital",
+	})
+	if diags := resource.Create(context.Background(), d, meta); !diags.HasError() {
+		t.Fatalf("Expected an error creating a rejected product")
+	}
+	if d.Id() != "" {
+		t.Errorf("Expected a rejected create not to be kept in state. Got ID: %s", d.Id())
+	}
+}
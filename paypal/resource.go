@@ -1,17 +1,27 @@
 package paypal
 
 import (
-	"github.com/hashicorp/terraform/helper/schema"
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 // TerraformResource The terraform resource interface that all resources implement
 type TerraformResource interface {
 	Resource() *schema.Resource
 	Schema() map[string]*schema.Schema
-	Create(*schema.ResourceData, interface{}) error
-	Read(*schema.ResourceData, interface{}) error
-	Update(*schema.ResourceData, interface{}) error
-	Delete(*schema.ResourceData, interface{}) error
+	Create(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics
+	Read(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics
+	Update(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics
+	Delete(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics
+}
+
+// TerraformDataSource The terraform data source interface that all data sources implement
+type TerraformDataSource interface {
+	DataSource() *schema.Resource
+	Schema() map[string]*schema.Schema
+	Read(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics
 }
 
 // SchemaSimplified Simplified schema used for tests
@@ -21,3 +31,24 @@ type SchemaSimplified struct {
 	Required bool
 	Nested   map[string]SchemaSimplified
 }
+
+// computedSchema Copy a resource schema for use in a data source, with every attribute computed
+func computedSchema(resourceSchema map[string]*schema.Schema) map[string]*schema.Schema {
+	dataSourceSchema := map[string]*schema.Schema{}
+	for key, attribute := range resourceSchema {
+		computed := &schema.Schema{
+			Type:        attribute.Type,
+			Computed:    true,
+			Sensitive:   attribute.Sensitive,
+			Description: attribute.Description,
+		}
+		switch elem := attribute.Elem.(type) {
+		case *schema.Resource:
+			computed.Elem = &schema.Resource{Schema: computedSchema(elem.Schema)}
+		case *schema.Schema:
+			computed.Elem = &schema.Schema{Type: elem.Type}
+		}
+		dataSourceSchema[key] = computed
+	}
+	return dataSourceSchema
+}
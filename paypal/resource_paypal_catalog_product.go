@@ -2,12 +2,12 @@ package paypal
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"strings"
 
-	"github.com/hashicorp/terraform/helper/schema"
-	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	paypalSdk "github.com/plutov/paypal/v4"
 
@@ -18,13 +18,14 @@ type CatalogProductResource struct{}
 
 func (r CatalogProductResource) Resource() *schema.Resource {
 	return &schema.Resource{
-		Schema: r.Schema(),
-		Create: r.Create,
-		Read:   r.Read,
-		Update: r.Update,
-		Delete: r.Delete,
+		Schema:        r.Schema(),
+		CreateContext: r.Create,
+		ReadContext:   r.Read,
+		UpdateContext: r.Update,
+		DeleteContext: r.Delete,
+		CustomizeDiff: customizeDiffEnvironment,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 	}
 }
@@ -63,34 +64,58 @@ func (r CatalogProductResource) Schema() map[string]*schema.Schema {
 			Optional:    true,
 			Description: "A product category from the following list: https://developer.paypal.com/api/catalog-products/v1/#products_get",
 		},
+		"environment": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The PayPal environment this product belongs to: sandbox, live or custom",
+		},
+		"request_id": requestIDSchema(),
+		"deletion_behavior": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "rename",
+			ValidateFunc: validation.StringInSlice(r.deletionBehaviors(), false),
+			Description:  fmt.Sprintf("What to do when the product is destroyed, as PayPal products cannot be deleted. rename prefixes the description with (removed), abandon only removes it from state, and archive adds archive_prefix and archive_suffix to the description. One of: %s", strings.Join(r.deletionBehaviors(), ",")),
+		},
+		"archive_prefix": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Added to the start of the description when an archived product is destroyed. Defaults to (archived) when neither archive_prefix nor archive_suffix is set",
+		},
+		"archive_suffix": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Added to the end of the description when an archived product is destroyed",
+		},
 	}
 }
 
 // Create - Creating a catalog product in Paypal
-func (r CatalogProductResource) Create(d *schema.ResourceData, m interface{}) error {
-	client := m.(*paypalSdk.Client)
+func (r CatalogProductResource) Create(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*ProviderMeta).Client
 
 	imageURL := d.Get("image_url").(string)
 	homeURL := d.Get("home_url").(string)
 	if imageURL == "" || homeURL == "" {
-		return errors.New("both image_url and home_url need to be set")
+		return diag.Errorf("both image_url and home_url need to be set")
 	}
 
-	product, err := client.CreateProduct(context.Background(), paypalSdk.Product{
-		Name:        d.Get("name").(string),
-		Description: d.Get("description").(string),
-		ImageUrl:    imageURL,
-		HomeUrl:     homeURL,
-		Type:        paypalSdk.ProductType(strings.ToUpper(d.Get("type").(string))),
-		Category:    paypalSdk.ProductCategory(strings.ToUpper(d.Get("category").(string))),
-	})
+	// Send a request ID so a retried create returns the same product
+	newProduct := r.sdkObjectFromResourceData(d)
+	requestID, err := createRequestID(d, m, newProduct)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	product, err := client.CreateProduct(withRequestID(ctx, requestID), newProduct)
 	if err != nil {
 		log.Printf("Error creating catalog product : %s", err.Error())
-		return err
+		return createFailed(d, err)
 	}
 
 	d.SetId(product.ID)
 	d.Set("name", product.Name)
+	d.Set("environment", m.(*ProviderMeta).Environment)
 	d.Set("description", product.Description)
 	d.Set("image_url", product.ImageUrl)
 	d.Set("home_url", product.HomeUrl)
@@ -103,75 +128,106 @@ func (r CatalogProductResource) Create(d *schema.ResourceData, m interface{}) er
 }
 
 // Read - Get a catalog product in Paypal
-func (r CatalogProductResource) Read(d *schema.ResourceData, m interface{}) error {
-	client := m.(*paypalSdk.Client)
+func (r CatalogProductResource) Read(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*ProviderMeta).Client
 
-	product, err := client.GetProduct(context.Background(), d.Id())
+	// A create that got no response is kept as it is until it is replaced
+	if isPendingID(d.Id()) {
+		return nil
+	}
+
+	product, err := client.GetProduct(ctx, d.Id())
+	if isNotFound(err) {
+		log.Printf("Catalog product %s no longer exists, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
 	if err != nil {
 		log.Printf("Error getting catalog product %s: %s", d.Id(), err.Error())
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.Set("name", product.Name)
+	d.Set("environment", m.(*ProviderMeta).Environment)
 	d.Set("description", product.Description)
 	d.Set("image_url", product.ImageUrl)
 	d.Set("home_url", product.HomeUrl)
 	d.Set("type", strings.ToLower(string(product.Type)))
 	d.Set("category", string(product.Category))
 
+	// Products created before deletion_behavior existed, or imported, have no value for it in state
+	if _, ok := d.GetOk("deletion_behavior"); !ok {
+		d.Set("deletion_behavior", "rename")
+	}
+
 	return nil
 }
 
 // Update - Update a catalog product in Paypal
-func (r CatalogProductResource) Update(d *schema.ResourceData, m interface{}) error {
-	client := m.(*paypalSdk.Client)
+func (r CatalogProductResource) Update(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*ProviderMeta).Client
 
 	imageURL := d.Get("image_url").(string)
 	homeURL := d.Get("home_url").(string)
 	if imageURL == "" || homeURL == "" {
-		return errors.New("both image_url and home_url need to be set")
+		return diag.Errorf("both image_url and home_url need to be set")
 	}
 
-	product := paypalSdk.Product{
-		ID:          d.Id(),
-		Name:        d.Get("name").(string),
-		Description: d.Get("description").(string),
-		ImageUrl:    imageURL,
-		HomeUrl:     homeURL,
-		Type:        paypalSdk.ProductType(strings.ToUpper(d.Get("type").(string))),
-		Category:    paypalSdk.ProductCategory(strings.ToUpper(d.Get("category").(string))),
-	}
+	product := r.sdkObjectFromResourceData(d)
+	product.ID = d.Id()
 
-	err := client.UpdateProduct(context.Background(), product)
+	err := client.UpdateProduct(ctx, product)
 	if err != nil {
 		log.Printf("Error updating catalog product %s: %s", d.Id(), err.Error())
-		return err
+		return diag.FromErr(err)
 	}
 
-	return r.Read(d, m)
+	return r.Read(ctx, d, m)
 }
 
-// Delete - Delete the a catalog product in Paypal - Products cannot be deleted
-// so we will update the name with a (removed) suffix and remove our reference to it
-func (r CatalogProductResource) Delete(d *schema.ResourceData, m interface{}) error {
-	client := m.(*paypalSdk.Client)
+// Delete - Delete the a catalog product in Paypal - Products cannot be deleted, or renamed,
+// so depending on deletion_behavior we mark the description as removed and remove our reference to it
+func (r CatalogProductResource) Delete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*ProviderMeta).Client
+
+	if isPendingID(d.Id()) {
+		return deletePendingCreate(d, m, r.sdkObjectFromResourceData(d))
+	}
+
+	prefix, suffix := "(removed) ", ""
+	switch d.Get("deletion_behavior").(string) {
+	case "abandon":
+		log.Printf("Abandoning catalog product %s, it is left unchanged in Paypal", d.Id())
+		d.SetId("")
+		return nil
+	case "archive":
+		prefix, suffix = d.Get("archive_prefix").(string), d.Get("archive_suffix").(string)
+		if prefix == "" && suffix == "" {
+			prefix = "(archived) "
+		}
+	}
 
 	// Get the current product
-	product, getErr := client.GetProduct(context.Background(), d.Id())
+	product, getErr := client.GetProduct(ctx, d.Id())
+	if isNotFound(getErr) {
+		d.SetId("")
+		return nil
+	}
 	if getErr != nil {
 		log.Printf("Error getting catalog product %s: %s", d.Id(), getErr.Error())
-		return getErr
+		return diag.FromErr(getErr)
 	}
 
-	// Update the name
-	product.Name = fmt.Sprintf("(removed) %s", product.Name)
-	product.Description = fmt.Sprintf("(removed) %s", product.Description)
+	// Mark the description, unless a previous destroy already did
+	description := r.markDescription(product.Description, prefix, suffix)
+	if description != product.Description {
+		product.Description = description
 
-	// Push the updated plan
-	updateErr := client.UpdateProduct(context.Background(), *product)
-	if updateErr != nil {
-		log.Printf("Error updating to mark as removed catalog product %s: %s", d.Id(), updateErr.Error())
-		return updateErr
+		updateErr := client.UpdateProduct(ctx, *product)
+		if updateErr != nil {
+			log.Printf("Error updating to mark as removed catalog product %s: %s", d.Id(), updateErr.Error())
+			return diag.FromErr(updateErr)
+		}
 	}
 
 	// Remove our ID reference
@@ -180,6 +236,34 @@ func (r CatalogProductResource) Delete(d *schema.ResourceData, m interface{}) er
 	return nil
 }
 
+// sdkObjectFromResourceData Build the product to send to PayPal from the resource data
+func (r CatalogProductResource) sdkObjectFromResourceData(d *schema.ResourceData) paypalSdk.Product {
+	return paypalSdk.Product{
+		Name:        d.Get("name").(string),
+		Description: d.Get("description").(string),
+		ImageUrl:    d.Get("image_url").(string),
+		HomeUrl:     d.Get("home_url").(string),
+		Type:        paypalSdk.ProductType(strings.ToUpper(d.Get("type").(string))),
+		Category:    paypalSdk.ProductCategory(strings.ToUpper(d.Get("category").(string))),
+	}
+}
+
+// markDescription Add a prefix and suffix to a description, skipping any it already has so marking twice changes nothing
+func (r CatalogProductResource) markDescription(description, prefix, suffix string) string {
+	if !strings.HasPrefix(description, prefix) {
+		description = prefix + description
+	}
+	if !strings.HasSuffix(description, suffix) {
+		description = description + suffix
+	}
+	return description
+}
+
+// deletionBehaviors List of acceptable deletion behaviors
+func (r CatalogProductResource) deletionBehaviors() []string {
+	return []string{"rename", "abandon", "archive"}
+}
+
 // productTypes List of acceptable product types
 func (r CatalogProductResource) productTypes() []string {
 	return []string{
@@ -1,11 +1,15 @@
 package paypal
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
 	"github.com/go-test/deep"
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/ollieparsley/terraform-provider-paypal/internal/fakepaypal"
+	paypalSdk "github.com/plutov/paypal/v4"
 )
 
 func TestProductResourceSchema(t *testing.T) {
@@ -43,6 +47,31 @@ func TestProductResourceSchema(t *testing.T) {
 			Optional: true,
 			Required: false,
 		},
+		"environment": {
+			Type:     schema.TypeString,
+			Optional: false,
+			Required: false,
+		},
+		"request_id": {
+			Type:     schema.TypeString,
+			Optional: false,
+			Required: false,
+		},
+		"deletion_behavior": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Required: false,
+		},
+		"archive_prefix": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Required: false,
+		},
+		"archive_suffix": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Required: false,
+		},
 	}
 
 	actualSchemaSimplified := map[string]SchemaSimplified{}
@@ -74,3 +103,165 @@ func TestProductTypes(t *testing.T) {
 		t.Errorf("Expected Event types didn't match. Got: %+v", actualProductTypes)
 	}
 }
+
+func TestProductResourceLifecycle(t *testing.T) {
+	server := fakepaypal.NewServer()
+	defer server.Close()
+	meta := testProviderMeta(t, server)
+
+	resource := CatalogProductResource{}
+	d := schema.TestResourceDataRaw(t, resource.Schema(), map[string]interface{}{
+		"name":        "Product",
+		"description": "A product",
+		"image_url":   "https://example.com/image.png",
+		"home_url":    "https://example.com",
+		"type":        "service",
+		"category":    "SOFTWARE",
+	})
+
+	if diags := resource.Create(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("Expected no error creating product. Got: %+v", diags)
+	}
+	if d.Id() == "" {
+		t.Fatalf("Expected product ID to be set")
+	}
+
+	d.Set("description", "An updated product")
+	if diags := resource.Update(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("Expected no error updating product. Got: %+v", diags)
+	}
+	if description := server.Product(d.Id())["description"]; description != "An updated product" {
+		t.Errorf("Expected updated description. Got: %v", description)
+	}
+	if d.Get("type") != "service" {
+		t.Errorf("Expected type to be read back. Got: %v", d.Get("type"))
+	}
+
+	id := d.Id()
+	if diags := resource.Delete(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("Expected no error deleting product. Got: %+v", diags)
+	}
+	if d.Id() != "" {
+		t.Errorf("Expected product ID to be removed")
+	}
+	if description := server.Product(id)["description"]; description != "(removed) An updated product" {
+		t.Errorf("Expected product to be marked as removed. Got: %v", description)
+	}
+}
+
+func TestProductResourceReadRemovesMissingProduct(t *testing.T) {
+	server := fakepaypal.NewServer()
+	defer server.Close()
+	meta := testProviderMeta(t, server)
+
+	resource := CatalogProductResource{}
+	d := schema.TestResourceDataRaw(t, resource.Schema(), map[string]interface{}{
+		"name":      "Product",
+		"image_url": "https://example.com/image.png",
+		"home_url":  "https://example.com",
+		"type":      "digital",
+	})
+	if diags := resource.Create(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("Expected no error creating product. Got: %+v", diags)
+	}
+
+	server.DeleteProduct(d.Id())
+	if diags := resource.Read(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("Expected no error reading a missing product. Got: %+v", diags)
+	}
+	if d.Id() != "" {
+		t.Errorf("Expected missing product to be removed from state. Got ID: %s", d.Id())
+	}
+}
+
+func TestProductResourceReadSetsDeletionBehavior(t *testing.T) {
+	server := fakepaypal.NewServer()
+	defer server.Close()
+	meta := testProviderMeta(t, server)
+
+	config := map[string]interface{}{
+		"name":      "Product",
+		"image_url": "https://example.com/image.png",
+		"home_url":  "https://example.com",
+		"type":      "digital",
+	}
+	ids := testCreateProducts(t, meta, paypalSdk.Product{Name: "Product", ImageUrl: "https://example.com/image.png", HomeUrl: "https://example.com", Type: paypalSdk.ProductTypeDigital})
+
+	// A product imported, or created before deletion_behavior existed, has only its ID in state
+	resource := CatalogProductResource{}.Resource()
+	d := resource.Data(&terraform.InstanceState{ID: ids[0]})
+	if diags := resource.ReadContext(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("Expected no error reading product. Got: %+v", diags)
+	}
+	if d.Get("deletion_behavior") != "rename" {
+		t.Errorf("Expected the default deletion_behavior to be read. Got: %v", d.Get("deletion_behavior"))
+	}
+
+	diff, err := resource.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(config), meta)
+	if err != nil {
+		t.Fatalf("Expected no error planning the product. Got: %s", err)
+	}
+	if diff != nil && !diff.Empty() {
+		t.Errorf("Expected no changes to the product. Got: %+v", diff.Attributes)
+	}
+}
+
+func TestProductResourceDeletionBehaviors(t *testing.T) {
+	server := fakepaypal.NewServer()
+	defer server.Close()
+	meta := testProviderMeta(t, server)
+
+	testCases := map[string]struct {
+		config              map[string]interface{}
+		expectedDescription string
+	}{
+		"rename": {
+			config:              map[string]interface{}{},
+			expectedDescription: "(removed) A product",
+		},
+		"abandon": {
+			config:              map[string]interface{}{"deletion_behavior": "abandon"},
+			expectedDescription: "A product",
+		},
+		"archive": {
+			config:              map[string]interface{}{"deletion_behavior": "archive"},
+			expectedDescription: "(archived) A product",
+		},
+		"archive with suffix": {
+			config:              map[string]interface{}{"deletion_behavior": "archive", "archive_prefix": "", "archive_suffix": " [archived]"},
+			expectedDescription: "A product [archived]",
+		},
+	}
+	for name, testCase := range testCases {
+		resource := CatalogProductResource{}
+		config := map[string]interface{}{
+			"name":        "Product",
+			"description": "A product",
+			"image_url":   "https://example.com/image.png",
+			"home_url":    "https://example.com",
+			"type":        "digital",
+		}
+		for key, value := range testCase.config {
+			config[key] = value
+		}
+		d := schema.TestResourceDataRaw(t, resource.Schema(), config)
+		if diags := resource.Create(context.Background(), d, meta); diags.HasError() {
+			t.Fatalf("%s: Expected no error creating product. Got: %+v", name, diags)
+		}
+
+		// Destroying twice, such as after an interrupted apply, only marks the product once
+		id := d.Id()
+		for i := 0; i < 2; i++ {
+			d.SetId(id)
+			if diags := resource.Delete(context.Background(), d, meta); diags.HasError() {
+				t.Fatalf("%s: Expected no error deleting product. Got: %+v", name, diags)
+			}
+			if d.Id() != "" {
+				t.Errorf("%s: Expected product ID to be removed", name)
+			}
+		}
+		if description := server.Product(id)["description"]; description != testCase.expectedDescription {
+			t.Errorf("%s: Expected description %q. Got: %v", name, testCase.expectedDescription, description)
+		}
+	}
+}
@@ -2,9 +2,12 @@ package paypal
 
 import (
 	"context"
+	"fmt"
 	"strings"
 
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	paypalSdk "github.com/plutov/paypal/v4"
 
@@ -17,13 +20,17 @@ type WebhookResource struct{}
 
 func (r WebhookResource) Resource() *schema.Resource {
 	return &schema.Resource{
-		Schema: r.Schema(),
-		Create: r.Create,
-		Read:   r.Read,
-		Update: r.Update,
-		Delete: r.Delete,
+		Schema:        r.Schema(),
+		CreateContext: r.Create,
+		ReadContext:   r.Read,
+		UpdateContext: r.Update,
+		DeleteContext: r.Delete,
+		CustomizeDiff: customdiff.All(
+			customizeDiffEnvironment,
+			r.customizeDiffEventTypes,
+		),
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 	}
 }
@@ -36,67 +43,83 @@ func (r WebhookResource) Schema() map[string]*schema.Schema {
 			Description: "The URL that Paypal will send notifications to",
 		},
 		"event_types": {
-			Type:        schema.TypeList,
-			Elem:        &schema.Schema{Type: schema.TypeString},
+			Type: schema.TypeSet,
+			Elem: &schema.Schema{
+				Type:             schema.TypeString,
+				ValidateDiagFunc: validateWebhookEventType,
+				StateFunc: func(v interface{}) string {
+					return strings.ToUpper(v.(string))
+				},
+			},
+			Set:         r.hashEventTypeName,
 			Required:    true,
-			Description: "A list of event types",
+			Description: "A set of event types, or * for all events. Names are not case sensitive",
 		},
+		"environment": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The PayPal environment this webhook belongs to: sandbox, live or custom",
+		},
+		"request_id": requestIDSchema(),
 	}
 }
 
 // Create - Creating notification webhook in Paypal
-func (r WebhookResource) Create(d *schema.ResourceData, m interface{}) error {
-	client := m.(*paypalSdk.Client)
+func (r WebhookResource) Create(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*ProviderMeta).Client
 
-	eventTypeNamesInterfaces := d.Get("event_types").([]interface{})
-	eventTypeNames := []string{}
-	for _, eventTypeNameInterface := range eventTypeNamesInterfaces {
-		eventTypeNames = append(eventTypeNames, eventTypeNameInterface.(string))
+	// Send a request ID so a retried create returns the same webhook
+	createRequest := r.createRequestFromResourceData(d)
+	requestID, err := createRequestID(d, m, createRequest)
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
-	//panic(fmt.Sprintf("eventTypeNames %+v", eventTypeNames))
-
-	eventTypes := r.eventTypeNamesToEventTypes(eventTypeNames)
-
-	webhook, err := client.CreateWebhook(context.Background(), &paypalSdk.CreateWebhookRequest{
-		URL:        d.Get("url").(string),
-		EventTypes: eventTypes,
-	})
+	webhook, err := client.CreateWebhook(withRequestID(ctx, requestID), createRequest)
 	if err != nil {
 		log.Printf("Error creating notifications webhook: %s", err.Error())
-		return err
+		return createFailed(d, err)
 	}
 
 	d.SetId(webhook.ID)
-	d.Set("url", webhook.URL)
-	d.Set("enabled_events", r.eventTypesToEventTypeNames(webhook.EventTypes))
 
 	log.Printf("Created notifications webhook with ID: %s", webhook.ID)
 
-	return nil
+	return r.Read(ctx, d, m)
 }
 
 // Read - Get notification webhook in Paypal
-func (r WebhookResource) Read(d *schema.ResourceData, m interface{}) error {
-	client := m.(*paypalSdk.Client)
+func (r WebhookResource) Read(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*ProviderMeta).Client
+
+	// A create that got no response is kept as it is until it is replaced
+	if isPendingID(d.Id()) {
+		return nil
+	}
 
-	webhook, err := client.GetWebhook(context.Background(), d.Id())
+	webhook, err := client.GetWebhook(ctx, d.Id())
+	if isNotFound(err) {
+		log.Printf("Notifications webhook %s no longer exists, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
 	if err != nil {
 		log.Printf("Error getting notifications webhook %s: %s", d.Id(), err.Error())
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.Set("url", webhook.URL)
-	d.Set("enabled_events", r.eventTypesToEventTypeNames(webhook.EventTypes))
+	d.Set("environment", m.(*ProviderMeta).Environment)
+	d.Set("event_types", r.eventTypesToEventTypeNames(webhook.EventTypes))
 
 	return nil
 }
 
 // Update - Update notification webhook in Paypal
-func (r WebhookResource) Update(d *schema.ResourceData, m interface{}) error {
-	client := m.(*paypalSdk.Client)
+func (r WebhookResource) Update(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*ProviderMeta).Client
 
-	webhook, err := client.UpdateWebhook(context.Background(), d.Id(), []paypalSdk.WebhookField{
+	_, err := client.UpdateWebhook(ctx, d.Id(), []paypalSdk.WebhookField{
 		{
 			Operation: "replace",
 			Path:      "/url",
@@ -105,28 +128,29 @@ func (r WebhookResource) Update(d *schema.ResourceData, m interface{}) error {
 		{
 			Operation: "replace",
 			Path:      "/event_types",
-			Value:     r.eventTypeNamesToEventTypes(d.Get("event_types").([]string)),
+			Value:     r.eventTypeNamesToEventTypes(r.eventTypeNamesFromResourceData(d)),
 		},
 	})
 	if err != nil {
 		log.Printf("Error updating notifications webhook %s: %s", d.Id(), err.Error())
-		return err
+		return diag.FromErr(err)
 	}
 
-	d.Set("url", webhook.URL)
-	d.Set("enabled_events", r.eventTypesToEventTypeNames(webhook.EventTypes))
-
-	return r.Read(d, m)
+	return r.Read(ctx, d, m)
 }
 
 // Delete - Delete the notification webhook in Paypal
-func (r WebhookResource) Delete(d *schema.ResourceData, m interface{}) error {
-	client := m.(*paypalSdk.Client)
+func (r WebhookResource) Delete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*ProviderMeta).Client
 
-	err := client.DeleteWebhook(context.Background(), d.Id())
-	if err != nil {
+	if isPendingID(d.Id()) {
+		return deletePendingCreate(d, m, r.createRequestFromResourceData(d))
+	}
+
+	err := client.DeleteWebhook(ctx, d.Id())
+	if err != nil && !isNotFound(err) {
 		log.Printf("Error deleting notifications webhook %s: %s", d.Id(), err.Error())
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId("")
@@ -134,6 +158,49 @@ func (r WebhookResource) Delete(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
+// customizeDiffEventTypes PayPal only accepts * on its own, as it already includes every event type
+func (r WebhookResource) customizeDiffEventTypes(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	eventTypeNames := d.Get("event_types").(*schema.Set)
+	if eventTypeNames.Len() > 1 && eventTypeNames.Contains(webhookEventTypeAll) {
+		return fmt.Errorf("event_types: %s subscribes to all events and cannot be combined with other event types", webhookEventTypeAll)
+	}
+	if !d.NewValueKnown("event_types") || !d.HasChange("event_types") {
+		return nil
+	}
+
+	names := []string{}
+	for _, name := range eventTypeNames.List() {
+		names = append(names, name.(string))
+	}
+	var client PaypalClient
+	if meta, ok := m.(*ProviderMeta); ok {
+		client = meta.Client
+	}
+	return checkWebhookEventTypes(ctx, client, "event_types", names)
+}
+
+// createRequestFromResourceData Build the webhook to create from the resource data
+func (r WebhookResource) createRequestFromResourceData(d *schema.ResourceData) *paypalSdk.CreateWebhookRequest {
+	return &paypalSdk.CreateWebhookRequest{
+		URL:        d.Get("url").(string),
+		EventTypes: r.eventTypeNamesToEventTypes(r.eventTypeNamesFromResourceData(d)),
+	}
+}
+
+// hashEventTypeName Hash event type names ignoring case, so a lower case name matches the upper case name PayPal returns
+func (r WebhookResource) hashEventTypeName(v interface{}) int {
+	return schema.HashString(strings.ToUpper(v.(string)))
+}
+
+// eventTypeNamesFromResourceData Get the event type names from the event_types set
+func (r WebhookResource) eventTypeNamesFromResourceData(d *schema.ResourceData) []string {
+	eventTypeNames := []string{}
+	for _, eventTypeName := range d.Get("event_types").(*schema.Set).List() {
+		eventTypeNames = append(eventTypeNames, eventTypeName.(string))
+	}
+	return eventTypeNames
+}
+
 // eventTypeNamesToEventTypes Convert the event_types object into an array of event type names
 func (r WebhookResource) eventTypeNamesToEventTypes(eventTypeNames []string) []paypalSdk.WebhookEventType {
 	eventTypes := []paypalSdk.WebhookEventType{}
@@ -1,11 +1,15 @@
 package paypal
 
 import (
+	"context"
 	"reflect"
+	"sort"
 	"testing"
 
 	"github.com/go-test/deep"
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/ollieparsley/terraform-provider-paypal/internal/fakepaypal"
 	paypalSdk "github.com/plutov/paypal/v4"
 )
 
@@ -19,11 +23,17 @@ func TestWebhookResourceSchema(t *testing.T) {
 			Description: "The URL that Paypal will send notifications to",
 		},
 		"event_types": {
-			Type:        schema.TypeList,
+			Type:        schema.TypeSet,
 			Elem:        &schema.Schema{Type: schema.TypeString},
 			Required:    true,
-			Description: "A list of event types",
+			Description: "A set of event types, or * for all events. Names are not case sensitive",
 		},
+		"environment": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The PayPal environment this webhook belongs to: sandbox, live or custom",
+		},
+		"request_id": requestIDSchema(),
 	}
 
 	differences := deep.Equal(expectedSchema, actualSchema)
@@ -74,3 +84,177 @@ func TestWebhookResourceEventTypesToEventTypesNames(t *testing.T) {
 		t.Errorf("Expected Event types names didn't match. Got: %+v", actualEventTypeNames)
 	}
 }
+
+func TestWebhookResourceEventTypeValidation(t *testing.T) {
+	resource := WebhookResource{}.Resource()
+
+	valid := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"url":         "https://example.com/webhook",
+		"event_types": []interface{}{"payment.sale.completed", "BILLING.SUBSCRIPTION.CREATED"},
+	})
+	if diags := resource.Validate(valid); diags.HasError() {
+		t.Errorf("Expected known event types to be valid. Got: %+v", diags)
+	}
+
+	server := fakepaypal.NewServer()
+	defer server.Close()
+	meta := testProviderMeta(t, server)
+
+	undocumented := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"url":         "https://example.com/webhook",
+		"event_types": []interface{}{"PAYMENT.CAPTURE.DECLINED"},
+	})
+	if _, err := resource.Diff(context.Background(), nil, undocumented, meta); err != nil {
+		t.Errorf("Expected an event type PayPal lists to be valid. Got: %s", err)
+	}
+
+	typo := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"url":         "https://example.com/webhook",
+		"event_types": []interface{}{"PAYMENT.SALE.COMPLETD"},
+	})
+	if _, err := resource.Diff(context.Background(), nil, typo, meta); err == nil {
+		t.Errorf("Expected an error planning an unknown event type")
+	}
+}
+
+func TestWebhookResourceLifecycle(t *testing.T) {
+	server := fakepaypal.NewServer()
+	defer server.Close()
+	meta := testProviderMeta(t, server)
+
+	resource := WebhookResource{}
+	config := map[string]interface{}{
+		"url":         "https://example.com/hook",
+		"event_types": []interface{}{"payment.sale.completed", "PAYMENT.SALE.REFUNDED"},
+	}
+	d := schema.TestResourceDataRaw(t, resource.Schema(), config)
+
+	if diags := resource.Create(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("Expected no error creating webhook. Got: %+v", diags)
+	}
+	if d.Id() == "" {
+		t.Fatalf("Expected webhook ID to be set")
+	}
+	if diags := resource.Read(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("Expected no error reading webhook. Got: %+v", diags)
+	}
+	if d.Get("url") != "https://example.com/hook" {
+		t.Errorf("Expected url to be read back. Got: %v", d.Get("url"))
+	}
+	if differences := deep.Equal([]string{"PAYMENT.SALE.COMPLETED", "PAYMENT.SALE.REFUNDED"}, testWebhookEventTypes(d)); len(differences) > 0 {
+		t.Errorf("Expected event types to be read back. Got differences: %+v", differences)
+	}
+
+	// Reordering or changing the case of event types is not a change
+	config["event_types"] = []interface{}{"PAYMENT.SALE.REFUNDED", "payment.sale.completed"}
+	diff, err := resource.Resource().Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(config), meta)
+	if err != nil {
+		t.Fatalf("Expected no error planning an unchanged webhook. Got: %s", err)
+	}
+	if diff != nil && !diff.Empty() {
+		t.Errorf("Expected reordered event types not to show a diff. Got: %+v", diff.Attributes)
+	}
+
+	config["event_types"] = []interface{}{"BILLING.SUBSCRIPTION.CREATED"}
+	d = testResourceDataUpdate(t, resource.Resource(), d, config)
+	if diags := resource.Update(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("Expected no error updating webhook. Got: %+v", diags)
+	}
+	if differences := deep.Equal([]string{"BILLING.SUBSCRIPTION.CREATED"}, testWebhookEventTypes(d)); len(differences) > 0 {
+		t.Errorf("Expected updated event types. Got differences: %+v", differences)
+	}
+
+	id := d.Id()
+	if diags := resource.Delete(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("Expected no error deleting webhook. Got: %+v", diags)
+	}
+	if server.Webhook(id) != nil {
+		t.Errorf("Expected webhook to be deleted")
+	}
+}
+
+// testWebhookEventTypes The sorted event type names in a webhook's resource data
+func testWebhookEventTypes(d *schema.ResourceData) []string {
+	eventTypeNames := WebhookResource{}.eventTypeNamesFromResourceData(d)
+	sort.Strings(eventTypeNames)
+	return eventTypeNames
+}
+
+func TestWebhookResourceReadDetectsDrift(t *testing.T) {
+	server := fakepaypal.NewServer()
+	defer server.Close()
+	meta := testProviderMeta(t, server)
+
+	resource := WebhookResource{}
+	d := schema.TestResourceDataRaw(t, resource.Schema(), map[string]interface{}{
+		"url":         "https://example.com/hook",
+		"event_types": []interface{}{"PAYMENT.SALE.COMPLETED"},
+	})
+	if diags := resource.Create(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("Expected no error creating webhook. Got: %+v", diags)
+	}
+
+	// Change the events outside of Terraform
+	_, err := meta.(*ProviderMeta).Client.UpdateWebhook(context.Background(), d.Id(), []paypalSdk.WebhookField{{
+		Operation: "replace",
+		Path:      "/event_types",
+		Value:     []paypalSdk.WebhookEventType{{Name: "CUSTOMER.DISPUTE.CREATED"}},
+	}})
+	if err != nil {
+		t.Fatalf("Expected no error updating webhook. Got: %s", err)
+	}
+
+	if diags := resource.Read(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("Expected no error reading webhook. Got: %+v", diags)
+	}
+	if differences := deep.Equal([]string{"CUSTOMER.DISPUTE.CREATED"}, testWebhookEventTypes(d)); len(differences) > 0 {
+		t.Errorf("Expected changed event types to be read back. Got differences: %+v", differences)
+	}
+}
+
+func TestWebhookResourceAllEventTypes(t *testing.T) {
+	server := fakepaypal.NewServer()
+	defer server.Close()
+	meta := testProviderMeta(t, server)
+
+	resource := WebhookResource{}
+	config := map[string]interface{}{
+		"url":         "https://example.com/hook",
+		"event_types": []interface{}{"*"},
+	}
+	d := schema.TestResourceDataRaw(t, resource.Schema(), config)
+	if diags := resource.Create(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("Expected no error creating webhook. Got: %+v", diags)
+	}
+	if differences := deep.Equal([]string{"*"}, testWebhookEventTypes(d)); len(differences) > 0 {
+		t.Errorf("Expected the wildcard to be read back. Got differences: %+v", differences)
+	}
+
+	config["event_types"] = []interface{}{"*", "PAYMENT.SALE.COMPLETED"}
+	if _, err := resource.Resource().Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(config), meta); err == nil {
+		t.Errorf("Expected an error combining the wildcard with other event types")
+	}
+}
+
+func TestWebhookResourceReadRemovesMissingWebhook(t *testing.T) {
+	server := fakepaypal.NewServer()
+	defer server.Close()
+	meta := testProviderMeta(t, server)
+
+	resource := WebhookResource{}
+	d := schema.TestResourceDataRaw(t, resource.Schema(), map[string]interface{}{
+		"url":         "https://example.com/hook",
+		"event_types": []interface{}{"PAYMENT.SALE.COMPLETED"},
+	})
+	if diags := resource.Create(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("Expected no error creating webhook. Got: %+v", diags)
+	}
+
+	server.DeleteWebhook(d.Id())
+	if diags := resource.Read(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("Expected no error reading a missing webhook. Got: %+v", diags)
+	}
+	if d.Id() != "" {
+		t.Errorf("Expected missing webhook to be removed from state. Got ID: %s", d.Id())
+	}
+}
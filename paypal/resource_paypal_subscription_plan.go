@@ -3,11 +3,12 @@ package paypal
 import (
 	"context"
 	"fmt"
-	"strconv"
 	"strings"
 
-	"github.com/hashicorp/terraform/helper/schema"
-	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	paypalSdk "github.com/plutov/paypal/v4"
 
@@ -18,13 +19,20 @@ type SubscriptionPlanResource struct{}
 
 func (r SubscriptionPlanResource) Resource() *schema.Resource {
 	return &schema.Resource{
-		Schema: r.Schema(),
-		Create: r.Create,
-		Read:   r.Read,
-		Update: r.Update,
-		Delete: r.Delete,
+		Schema:        r.Schema(),
+		CreateContext: r.Create,
+		ReadContext:   r.Read,
+		UpdateContext: r.Update,
+		DeleteContext: r.Delete,
+		CustomizeDiff: customdiff.All(
+			customizeDiffEnvironment,
+			r.customizeDiffBillingCycles,
+			r.customizeDiffPricingSchemes,
+			r.customizeDiffMoney,
+			customdiff.ValidateChange("status", r.validateStatusChange),
+		),
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 	}
 }
@@ -47,10 +55,23 @@ func (r SubscriptionPlanResource) Schema() map[string]*schema.Schema {
 			Description: "The drescription of the subscription plan",
 		},
 		"status": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.StringInSlice(r.statuses(), false),
+			Description:  fmt.Sprintf("The status of the subscription plan. Plans are created ACTIVE by default and cannot return to CREATED. One of: %s", strings.Join(r.statuses(), ",")),
+		},
+		"environment": {
 			Type:        schema.TypeString,
-			Optional:    true,
 			Computed:    true,
-			Description: "The status of the subscription plan",
+			Description: "The PayPal environment this subscription plan belongs to: sandbox, live or custom",
+		},
+		"request_id": requestIDSchema(),
+		"currency_code": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validateCurrencyCode,
+			Description:  "The three-character ISO-4217 currency code used by every price and the setup fee that does not set its own",
 		},
 		"quantity_supported": {
 			Type:        schema.TypeBool,
@@ -112,22 +133,41 @@ func (r SubscriptionPlanResource) Schema() map[string]*schema.Schema {
 									Optional: true,
 									Computed: true,
 								},
+								"pricing_model": {
+									Type:         schema.TypeString,
+									Optional:     true,
+									ValidateFunc: validation.StringInSlice(r.pricingModels(), true),
+									Description:  fmt.Sprintf("The pricing model for tiered plans. Required with tiers. One of: %s", strings.Join(r.pricingModels(), ",")),
+								},
 								"fixed_price": {
-									Type:     schema.TypeList,
-									MaxItems: 1,
-									Required: true,
+									Type:        schema.TypeList,
+									MaxItems:    1,
+									Optional:    true,
+									Description: "The fixed amount to charge for the subscription. Conflicts with tiers",
+									Elem:        moneyResource(),
+								},
+								"tiers": {
+									Type:        schema.TypeList,
+									Optional:    true,
+									Description: "The pricing tiers for the volume and tiered pricing models. Conflicts with fixed_price",
 									Elem: &schema.Resource{
 										Schema: map[string]*schema.Schema{
-											"value": {
-												Type:             schema.TypeString,
-												Required:         true,
-												DiffSuppressFunc: r.diffSuppressFuncForFloats,
-												Description:      "More info: https://developer.paypal.com/docs/api/payments.billing-plans/v1/#definition-currency",
+											"starting_quantity": {
+												Type:        schema.TypeString,
+												Required:    true,
+												Description: "The starting quantity for the tier",
 											},
-											"currency_code": {
+											"ending_quantity": {
 												Type:        schema.TypeString,
+												Optional:    true,
+												Description: "The ending quantity for the tier. Leave unset for the last tier",
+											},
+											"amount": {
+												Type:        schema.TypeList,
+												MaxItems:    1,
 												Required:    true,
-												Description: "The three-character ISO-4217 currency code",
+												Description: "The price for the tier",
+												Elem:        moneyResource(),
 											},
 										},
 									},
@@ -140,45 +180,37 @@ func (r SubscriptionPlanResource) Schema() map[string]*schema.Schema {
 		},
 
 		"payment_preferences": {
-			Type:     schema.TypeList,
-			MaxItems: 1,
-			Required: true,
+			Type:        schema.TypeList,
+			MaxItems:    1,
+			Optional:    true,
+			Computed:    true,
+			Description: "The payment preferences for a subscription. Defaults to PayPal's preferences when unset",
 			Elem: &schema.Resource{
 				Schema: map[string]*schema.Schema{
 					"auto_bill_outstanding": {
 						Type:        schema.TypeBool,
-						Required:    true,
+						Optional:    true,
+						Default:     true,
 						Description: "Indicates whether to automatically bill the outstanding amount in the next billing cycle.",
 					},
 					"setup_fee": {
 						Type:        schema.TypeList,
 						MaxItems:    1,
-						Required:    true,
+						Optional:    true,
+						Computed:    true,
 						Description: "The initial set-up fee for the service.",
-						Elem: &schema.Resource{
-							Schema: map[string]*schema.Schema{
-								"value": {
-									Type:             schema.TypeString,
-									Required:         true,
-									DiffSuppressFunc: r.diffSuppressFuncForFloats,
-									Description:      "More info: https://developer.paypal.com/docs/api/payments.billing-plans/v1/#definition-currency",
-								},
-								"currency_code": {
-									Type:        schema.TypeString,
-									Required:    true,
-									Description: "The three-character ISO-4217 currency code",
-								},
-							},
-						},
+						Elem:        moneyResource(),
 					},
 					"payment_failure_threshold": {
 						Type:        schema.TypeInt,
-						Required:    true,
+						Optional:    true,
+						Computed:    true,
 						Description: "The maximum number of payment failures before a subscription is suspended. For example, if payment_failure_threshold is 2, the subscription automatically updates to the SUSPEND state if two consecutive payments fail.",
 					},
 					"setup_fee_failure_action": {
 						Type:         schema.TypeString,
-						Required:     true,
+						Optional:     true,
+						Computed:     true,
 						ValidateFunc: validation.StringInSlice(r.setupFeeFailureActions(), true),
 						Description:  fmt.Sprintf("One of: %s", strings.Join(r.setupFeeFailureActions(), ",")),
 					},
@@ -195,7 +227,7 @@ func (r SubscriptionPlanResource) Schema() map[string]*schema.Schema {
 					"percentage": {
 						Type:             schema.TypeString,
 						Required:         true,
-						DiffSuppressFunc: r.diffSuppressFuncForFloats,
+						DiffSuppressFunc: diffSuppressDecimals,
 					},
 					"inclusive": {
 						Type:     schema.TypeBool,
@@ -208,131 +240,130 @@ func (r SubscriptionPlanResource) Schema() map[string]*schema.Schema {
 }
 
 // Create - Creating a subscription plan in Paypal
-func (r SubscriptionPlanResource) Create(d *schema.ResourceData, m interface{}) error {
-	client := m.(*paypalSdk.Client)
+func (r SubscriptionPlanResource) Create(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*ProviderMeta).Client
 
-	subscriptionPlan := r.sdkObjectFromResourceData(d)
+	subscriptionPlan := r.createObjectFromResourceData(d)
 
-	// Create the plan
-	billingResponse, err := client.CreateSubscriptionPlan(context.Background(), subscriptionPlan)
+	// Inactive plans are created active, then deactivated after creation
+	status := paypalSdk.SubscriptionPlanStatus(d.Get("status").(string))
 
+	// Create the plan, with a request ID so a retried create returns the same plan
+	requestID, err := createRequestID(d, m, subscriptionPlan)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	billingResponse, err := client.CreateSubscriptionPlan(withRequestID(ctx, requestID), subscriptionPlan)
 	if err != nil {
 		log.Printf("Error creating billing plan: %s", err.Error())
-		return err
+		return createFailed(d, err)
 	}
 
 	d.SetId(billingResponse.ID)
-	r.Read(d, m)
 
 	log.Printf("Created billing plan with ID: %s", billingResponse.ID)
 
-	return nil
+	if status == paypalSdk.SubscriptionPlanStatusInactive {
+		if err := client.DeactivateSubscriptionPlans(ctx, billingResponse.ID); err != nil {
+			log.Printf("Error deactivating subscription plan %s: %s", billingResponse.ID, err.Error())
+			return diag.FromErr(err)
+		}
+	}
+
+	return r.Read(ctx, d, m)
 }
 
 // Read - Get a subscription plan in Paypal - https://developer.paypal.com/docs/api/subscriptions/v1/#plans_get
-func (r SubscriptionPlanResource) Read(d *schema.ResourceData, m interface{}) error {
-	client := m.(*paypalSdk.Client)
+func (r SubscriptionPlanResource) Read(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*ProviderMeta).Client
 
-	subscriptionPlan, err := client.GetSubscriptionPlan(context.Background(), d.Id())
-	if err != nil {
-		log.Printf("Error getting subscription plan %s: %s", d.Id(), err.Error())
-		return err
+	// A create that got no response is kept as it is until it is replaced
+	if isPendingID(d.Id()) {
+		return nil
 	}
 
-	// Taxes to resource data map
-	taxes := []map[string]interface{}{{
-		"percentage": subscriptionPlan.Taxes.Percentage,
-		"inclusive":  subscriptionPlan.Taxes.Inclusive,
-	}}
-
-	// Payment preferences to map
-	paymentPreferences := []map[string]interface{}{{
-		"auto_bill_outstanding": subscriptionPlan.PaymentPreferences.AutoBillOutstanding,
-		"setup_fee": map[string]interface{}{
-			"value":         subscriptionPlan.PaymentPreferences.SetupFee.Value,
-			"currency_code": subscriptionPlan.PaymentPreferences.SetupFee.Currency,
-		},
-		"payment_failure_threshold": subscriptionPlan.PaymentPreferences.PaymentFailureThreshold,
-		"setup_fee_failure_action":  subscriptionPlan.PaymentPreferences.SetupFeeFailureAction,
-	}}
-
-	// Billing cycles to array of maps
-	billingCycles := []map[string]interface{}{}
-	for _, billingCycleObj := range subscriptionPlan.BillingCycles {
-		billingCycle := map[string]interface{}{
-			"sequence":     billingCycleObj.Sequence,
-			"total_cycles": billingCycleObj.TotalCycles,
-			"tenure_type":  strings.ToLower(string(billingCycleObj.TenureType)),
-			"frequency": []map[string]interface{}{{
-				"interval_unit":  strings.ToLower(string(billingCycleObj.Frequency.IntervalUnit)),
-				"interval_count": billingCycleObj.Frequency.IntervalCount,
-			}},
-			"pricing_scheme": []map[string]interface{}{{
-				"version": billingCycleObj.PricingScheme.Version,
-				"fixed_price": []map[string]interface{}{{
-					"value":         billingCycleObj.PricingScheme.FixedPrice.Value,
-					"currency_code": billingCycleObj.PricingScheme.FixedPrice.Currency,
-				}},
-			}},
-		}
-		billingCycles = append(billingCycles, billingCycle)
+	subscriptionPlan, err := client.GetSubscriptionPlan(ctx, d.Id())
+	if isNotFound(err) {
+		log.Printf("Subscription plan %s no longer exists, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		log.Printf("Error getting subscription plan %s: %s", d.Id(), err.Error())
+		return diag.FromErr(err)
 	}
 
 	d.SetId(subscriptionPlan.ID)
-	d.Set("status", subscriptionPlan.Status)
-	d.Set("product_id", subscriptionPlan.ProductId)
-	d.Set("name", subscriptionPlan.Name)
-	d.Set("description", subscriptionPlan.Description)
-	d.Set("quantity_supported", subscriptionPlan.QuantitySupported)
-	d.Set("taxes", taxes)
-	d.Set("payment_preferences", paymentPreferences)
-	d.Set("billing_cycle", billingCycles)
+	d.Set("environment", m.(*ProviderMeta).Environment)
+	for key, value := range r.flatten(subscriptionPlan) {
+		d.Set(key, value)
+	}
 
 	return nil
 }
 
 // Update - Update a subscription plan in Paypal
-func (r SubscriptionPlanResource) Update(d *schema.ResourceData, m interface{}) error {
-	client := m.(*paypalSdk.Client)
+func (r SubscriptionPlanResource) Update(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*ProviderMeta).Client
 
 	subscriptionPlan := r.sdkObjectFromResourceData(d)
 
 	// Update as much as we can from subscription plan
-	err := client.UpdateSubscriptionPlan(context.Background(), subscriptionPlan)
+	err := client.UpdateSubscriptionPlan(ctx, subscriptionPlan)
 	if err != nil {
 		log.Printf("Error updating subscription plan %s: %s", d.Id(), err.Error())
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Update pricing separately
-	pricingSchemeUpdates := []paypalSdk.PricingSchemeUpdate{}
+	pricingSchemeUpdates := []PricingSchemeUpdate{}
 	for _, billingCycleObj := range subscriptionPlan.BillingCycles {
-		pricingSchemeUpdates = append(pricingSchemeUpdates, paypalSdk.PricingSchemeUpdate{
+		pricingSchemeUpdates = append(pricingSchemeUpdates, PricingSchemeUpdate{
 			BillingCycleSequence: billingCycleObj.Sequence,
 			PricingScheme:        billingCycleObj.PricingScheme,
 		})
 	}
-	pricingErr := client.UpdateSubscriptionPlanPricing(context.Background(), subscriptionPlan.ID, pricingSchemeUpdates)
+	pricingErr := client.UpdateSubscriptionPlanPricing(ctx, subscriptionPlan.ID, pricingSchemeUpdates)
 	if pricingErr != nil {
 		log.Printf("Error updating subcription plan pricing %s: %s", d.Id(), pricingErr.Error())
-		return err
+		return diag.FromErr(pricingErr)
+	}
+
+	// Move the plan to its new status
+	if d.HasChange("status") {
+		var statusErr error
+		switch subscriptionPlan.Status {
+		case paypalSdk.SubscriptionPlanStatusActive:
+			statusErr = client.ActivateSubscriptionPlan(ctx, d.Id())
+		case paypalSdk.SubscriptionPlanStatusInactive:
+			statusErr = client.DeactivateSubscriptionPlans(ctx, d.Id())
+		}
+		if statusErr != nil {
+			log.Printf("Error updating subscription plan status %s: %s", d.Id(), statusErr.Error())
+			return diag.FromErr(statusErr)
+		}
 	}
 
-	return r.Read(d, m)
+	return r.Read(ctx, d, m)
 }
 
 // Delete - Delete a subscription plan in Paypal - Subscription plans cannot be deleted
 // so we will update the name with a (removed) suffix and remove our reference to it
-func (r SubscriptionPlanResource) Delete(d *schema.ResourceData, m interface{}) error {
+func (r SubscriptionPlanResource) Delete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if isPendingID(d.Id()) {
+		return deletePendingCreate(d, m, r.createObjectFromResourceData(d))
+	}
 
 	// Deactivate and delete
 	// https://developer.paypal.com/docs/api/subscriptions/v1/#plans_deactivate
-	// we cannot delete, but we can deactivate
-	client := m.(*paypalSdk.Client)
-	err := client.DeactivateSubscriptionPlans(context.Background(), d.Id())
-	if err != nil {
-		log.Printf("Error deactivating subscription plan %s: %s", d.Id(), err.Error())
-		return err
+	// we cannot delete, but we can deactivate. Only active plans can be deactivated
+	client := m.(*ProviderMeta).Client
+	if d.Get("status").(string) == string(paypalSdk.SubscriptionPlanStatusActive) {
+		err := client.DeactivateSubscriptionPlans(ctx, d.Id())
+		if err != nil && !isNotFound(err) {
+			log.Printf("Error deactivating subscription plan %s: %s", d.Id(), err.Error())
+			return diag.FromErr(err)
+		}
 	}
 
 	// Even though we can't delete it, we can remove our id reference
@@ -341,13 +372,113 @@ func (r SubscriptionPlanResource) Delete(d *schema.ResourceData, m interface{})
 	return nil
 }
 
-// sdkOjectFromResourceData Get a PayPal SDK object from resource data
-func (r SubscriptionPlanResource) sdkObjectFromResourceData(d *schema.ResourceData) paypalSdk.SubscriptionPlan {
-	subscriptionPlan := paypalSdk.SubscriptionPlan{
+// flatten Map a PayPal subscription plan to resource data attributes
+func (r SubscriptionPlanResource) flatten(subscriptionPlan *SubscriptionPlan) map[string]interface{} {
+
+	// Taxes to resource data map
+	taxes := []map[string]interface{}{}
+	if subscriptionPlan.Taxes != nil {
+		taxes = append(taxes, map[string]interface{}{
+			"percentage": subscriptionPlan.Taxes.Percentage,
+			"inclusive":  subscriptionPlan.Taxes.Inclusive,
+		})
+	}
+
+	// Payment preferences to map
+	paymentPreferences := []map[string]interface{}{}
+	if subscriptionPlan.PaymentPreferences != nil {
+		setupFees := []map[string]interface{}{}
+		if subscriptionPlan.PaymentPreferences.SetupFee != nil {
+			setupFees = append(setupFees, map[string]interface{}{
+				"value":         subscriptionPlan.PaymentPreferences.SetupFee.Value,
+				"currency_code": subscriptionPlan.PaymentPreferences.SetupFee.Currency,
+			})
+		}
+		paymentPreferences = append(paymentPreferences, map[string]interface{}{
+			"auto_bill_outstanding":     subscriptionPlan.PaymentPreferences.AutoBillOutstanding,
+			"setup_fee":                 setupFees,
+			"payment_failure_threshold": subscriptionPlan.PaymentPreferences.PaymentFailureThreshold,
+			"setup_fee_failure_action":  strings.ToLower(string(subscriptionPlan.PaymentPreferences.SetupFeeFailureAction)),
+		})
+	}
+
+	// Billing cycles to array of maps
+	billingCycles := []map[string]interface{}{}
+	for _, billingCycleObj := range subscriptionPlan.BillingCycles {
+		billingCycle := map[string]interface{}{
+			"sequence":     billingCycleObj.Sequence,
+			"total_cycles": billingCycleObj.TotalCycles,
+			"tenure_type":  strings.ToLower(string(billingCycleObj.TenureType)),
+			"frequency": []map[string]interface{}{{
+				"interval_unit":  strings.ToLower(string(billingCycleObj.Frequency.IntervalUnit)),
+				"interval_count": billingCycleObj.Frequency.IntervalCount,
+			}},
+			"pricing_scheme": []map[string]interface{}{r.flattenPricingScheme(billingCycleObj.PricingScheme)},
+		}
+		billingCycles = append(billingCycles, billingCycle)
+	}
+
+	return map[string]interface{}{
+		"status":              string(subscriptionPlan.Status),
+		"product_id":          subscriptionPlan.ProductId,
+		"name":                subscriptionPlan.Name,
+		"description":         subscriptionPlan.Description,
+		"quantity_supported":  subscriptionPlan.QuantitySupported,
+		"taxes":               taxes,
+		"payment_preferences": paymentPreferences,
+		"billing_cycle":       billingCycles,
+	}
+}
+
+// flattenPricingScheme Map a billing cycle's pricing scheme to resource data attributes
+func (r SubscriptionPlanResource) flattenPricingScheme(pricingScheme PricingScheme) map[string]interface{} {
+	fixedPrices := []map[string]interface{}{}
+	if pricingScheme.FixedPrice != nil {
+		fixedPrices = append(fixedPrices, map[string]interface{}{
+			"value":         pricingScheme.FixedPrice.Value,
+			"currency_code": pricingScheme.FixedPrice.Currency,
+		})
+	}
+
+	tiers := []map[string]interface{}{}
+	for _, tier := range pricingScheme.Tiers {
+		tiers = append(tiers, map[string]interface{}{
+			"starting_quantity": tier.StartingQuantity,
+			"ending_quantity":   tier.EndingQuantity,
+			"amount": []map[string]interface{}{{
+				"value":         tier.Amount.Value,
+				"currency_code": tier.Amount.Currency,
+			}},
+		})
+	}
+
+	return map[string]interface{}{
+		"version":       pricingScheme.Version,
+		"pricing_model": strings.ToLower(pricingScheme.PricingModel),
+		"fixed_price":   fixedPrices,
+		"tiers":         tiers,
+	}
+}
+
+// createObjectFromResourceData Build the subscription plan to create from the resource data. Plans can only be
+// created as CREATED or ACTIVE, so an inactive plan is created active
+func (r SubscriptionPlanResource) createObjectFromResourceData(d *schema.ResourceData) SubscriptionPlan {
+	subscriptionPlan := r.sdkObjectFromResourceData(d)
+	subscriptionPlan.ID = ""
+	if subscriptionPlan.Status == paypalSdk.SubscriptionPlanStatusInactive {
+		subscriptionPlan.Status = paypalSdk.SubscriptionPlanStatusActive
+	}
+	return subscriptionPlan
+}
+
+// sdkOjectFromResourceData Get a PayPal subscription plan object from resource data
+func (r SubscriptionPlanResource) sdkObjectFromResourceData(d *schema.ResourceData) SubscriptionPlan {
+	subscriptionPlan := SubscriptionPlan{
 		ID:                d.Id(),
 		ProductId:         d.Get("product_id").(string),
 		Name:              d.Get("name").(string),
 		Description:       d.Get("description").(string),
+		Status:            paypalSdk.SubscriptionPlanStatus(d.Get("status").(string)),
 		QuantitySupported: d.Get("quantity_supported").(bool),
 	}
 
@@ -363,26 +494,25 @@ func (r SubscriptionPlanResource) sdkObjectFromResourceData(d *schema.ResourceDa
 
 	// Payment preferences
 	paymentPreferencesData := d.Get("payment_preferences").([]interface{})
-	if len(taxesData) == 1 {
+	if len(paymentPreferencesData) == 1 && paymentPreferencesData[0] != nil {
 		paymentPreferenceData := paymentPreferencesData[0].(map[string]interface{})
-		setupFeesData := paymentPreferenceData["setup_fee"].([]interface{})
-		setupFeeData := setupFeesData[0].(map[string]interface{})
 
-		subscriptionPlan.PaymentPreferences = &paypalSdk.PaymentPreferences{
+		subscriptionPlan.PaymentPreferences = &PaymentPreferences{
 			AutoBillOutstanding:     paymentPreferenceData["auto_bill_outstanding"].(bool),
 			PaymentFailureThreshold: paymentPreferenceData["payment_failure_threshold"].(int),
-			SetupFee: &paypalSdk.Money{
-				Currency: setupFeeData["currency_code"].(string),
-				Value:    setupFeeData["value"].(string),
-			},
-			SetupFeeFailureAction: paypalSdk.SetupFeeFailureAction(paymentPreferenceData["setup_fee_failure_action"].(string)),
+			SetupFeeFailureAction:   paypalSdk.SetupFeeFailureAction(strings.ToUpper(paymentPreferenceData["setup_fee_failure_action"].(string))),
 		}
 
+		// Setup fee
+		setupFeesData := paymentPreferenceData["setup_fee"].([]interface{})
+		if len(setupFeesData) == 1 && setupFeesData[0] != nil {
+			subscriptionPlan.PaymentPreferences.SetupFee = moneyFromResourceData(d, setupFeesData[0].(map[string]interface{}))
+		}
 	}
 
 	// Billing cycle
 	billingCyclesData := d.Get("billing_cycle").([]interface{})
-	subscriptionPlan.BillingCycles = []paypalSdk.BillingCycle{}
+	subscriptionPlan.BillingCycles = []BillingCycle{}
 	for _, billingCycleInterfaceData := range billingCyclesData {
 		billingCycleData := billingCycleInterfaceData.(map[string]interface{})
 
@@ -397,22 +527,30 @@ func (r SubscriptionPlanResource) sdkObjectFromResourceData(d *schema.ResourceDa
 		// Pricing Scheme
 		pricingSchemesData := billingCycleData["pricing_scheme"].([]interface{})
 		pricingSchemeData := pricingSchemesData[0].(map[string]interface{})
-		billingCyclePricingScheme := paypalSdk.PricingScheme{
-			Version: pricingSchemeData["version"].(int),
+		billingCyclePricingScheme := PricingScheme{
+			Version:      pricingSchemeData["version"].(int),
+			PricingModel: strings.ToUpper(pricingSchemeData["pricing_model"].(string)),
 		}
 
 		// Pricing scheme - Fixed price
 		fixedPricesData := pricingSchemeData["fixed_price"].([]interface{})
+		if len(fixedPricesData) == 1 {
+			billingCyclePricingScheme.FixedPrice = moneyFromResourceData(d, fixedPricesData[0].(map[string]interface{}))
+		}
 
-		fixedPriceData := fixedPricesData[0].(map[string]interface{})
-		billingCyclePricingSchemeDataFixedPrice := paypalSdk.Money{
-			Currency: fixedPriceData["currency_code"].(string),
-			Value:    fixedPriceData["value"].(string),
+		// Pricing scheme - Tiers
+		for _, tierInterfaceData := range pricingSchemeData["tiers"].([]interface{}) {
+			tierData := tierInterfaceData.(map[string]interface{})
+			amount := moneyFromResourceData(d, tierData["amount"].([]interface{})[0].(map[string]interface{}))
+			billingCyclePricingScheme.Tiers = append(billingCyclePricingScheme.Tiers, PricingTier{
+				StartingQuantity: tierData["starting_quantity"].(string),
+				EndingQuantity:   tierData["ending_quantity"].(string),
+				Amount:           *amount,
+			})
 		}
-		billingCyclePricingScheme.FixedPrice = billingCyclePricingSchemeDataFixedPrice
 
 		// Put it all together
-		billingCycle := paypalSdk.BillingCycle{
+		billingCycle := BillingCycle{
 			Sequence:      billingCycleData["sequence"].(int),
 			TotalCycles:   billingCycleData["total_cycles"].(int),
 			TenureType:    paypalSdk.TenureType(billingCycleData["tenure_type"].(string)),
@@ -426,6 +564,135 @@ func (r SubscriptionPlanResource) sdkObjectFromResourceData(d *schema.ResourceDa
 	return subscriptionPlan
 }
 
+// customizeDiffBillingCycles Check the billing cycles follow PayPal's rules: sequences are unique,
+// there is at most one regular cycle, trials run before it and trials have a finite number of cycles
+func (r SubscriptionPlanResource) customizeDiffBillingCycles(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	sequences := map[int]int{}
+	regularSequence, regularIndex := 0, -1
+	trialIndexes := []int{}
+
+	for i, billingCycleInterfaceData := range d.Get("billing_cycle").([]interface{}) {
+		billingCycleData, ok := billingCycleInterfaceData.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		// Values only known at apply time are checked by PayPal instead
+		key := fmt.Sprintf("billing_cycle.%d", i)
+		if !d.NewValueKnown(key+".sequence") || !d.NewValueKnown(key+".tenure_type") || !d.NewValueKnown(key+".total_cycles") {
+			continue
+		}
+
+		sequence := billingCycleData["sequence"].(int)
+		if previous, ok := sequences[sequence]; ok {
+			return fmt.Errorf("billing_cycle.%d: sequence %d is already used by billing_cycle.%d", i, sequence, previous)
+		}
+		sequences[sequence] = i
+
+		switch strings.ToLower(billingCycleData["tenure_type"].(string)) {
+		case "regular":
+			if regularIndex >= 0 {
+				return fmt.Errorf("billing_cycle.%d: only one regular billing cycle is allowed, billing_cycle.%d is already regular", i, regularIndex)
+			}
+			regularSequence, regularIndex = sequence, i
+		case "trial":
+			if billingCycleData["total_cycles"].(int) == 0 {
+				return fmt.Errorf("billing_cycle.%d: trial billing cycles must have a total_cycles between 1 and 999", i)
+			}
+			trialIndexes = append(trialIndexes, i)
+		}
+	}
+
+	if regularIndex >= 0 {
+		for _, i := range trialIndexes {
+			if d.Get(fmt.Sprintf("billing_cycle.%d.sequence", i)).(int) > regularSequence {
+				return fmt.Errorf("billing_cycle.%d: trial billing cycles must have a lower sequence than the regular billing cycle (%d)", i, regularSequence)
+			}
+		}
+	}
+	return nil
+}
+
+// customizeDiffPricingSchemes Check each billing cycle is priced either with a fixed price, or
+// with tiers and the pricing model they follow
+func (r SubscriptionPlanResource) customizeDiffPricingSchemes(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	for i, billingCycleInterfaceData := range d.Get("billing_cycle").([]interface{}) {
+		billingCycleData, ok := billingCycleInterfaceData.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		pricingSchemesData := billingCycleData["pricing_scheme"].([]interface{})
+		if len(pricingSchemesData) != 1 || pricingSchemesData[0] == nil {
+			continue
+		}
+		pricingSchemeData := pricingSchemesData[0].(map[string]interface{})
+
+		hasFixedPrice := len(pricingSchemeData["fixed_price"].([]interface{})) > 0
+		hasTiers := len(pricingSchemeData["tiers"].([]interface{})) > 0
+		hasPricingModel := pricingSchemeData["pricing_model"].(string) != ""
+
+		switch {
+		case hasFixedPrice && hasTiers:
+			return fmt.Errorf("billing_cycle.%d.pricing_scheme: only one of fixed_price or tiers can be set", i)
+		case !hasFixedPrice && !hasTiers:
+			return fmt.Errorf("billing_cycle.%d.pricing_scheme: one of fixed_price or tiers must be set", i)
+		case hasTiers && !hasPricingModel:
+			return fmt.Errorf("billing_cycle.%d.pricing_scheme: pricing_model is required with tiers", i)
+		case hasFixedPrice && hasPricingModel:
+			return fmt.Errorf("billing_cycle.%d.pricing_scheme: pricing_model can only be set with tiers", i)
+		}
+	}
+	return nil
+}
+
+// validateStatusChange PayPal has no way to move an activated plan back to CREATED
+func (r SubscriptionPlanResource) validateStatusChange(ctx context.Context, old, new, m interface{}) error {
+	if old.(string) != "" && old.(string) != string(paypalSdk.SubscriptionPlanStatusCreated) && new.(string) == string(paypalSdk.SubscriptionPlanStatusCreated) {
+		return fmt.Errorf("status: a %s plan cannot be moved back to %s", old, new)
+	}
+	return nil
+}
+
+// customizeDiffMoney Check every price and the setup fee share one currency, and have no more
+// decimal places than it allows
+func (r SubscriptionPlanResource) customizeDiffMoney(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	return validateMoneyDiff(d, r.moneyKeys(d))
+}
+
+// moneyKeys The keys of every money block in the plan: fixed prices, tier amounts and the setup fee
+func (r SubscriptionPlanResource) moneyKeys(d *schema.ResourceDiff) []string {
+	keys := []string{}
+	for i := 0; i < d.Get("billing_cycle.#").(int); i++ {
+		pricingKey := fmt.Sprintf("billing_cycle.%d.pricing_scheme.0", i)
+		if d.Get(pricingKey+".fixed_price.#").(int) > 0 {
+			keys = append(keys, pricingKey+".fixed_price.0")
+		}
+		for j := 0; j < d.Get(pricingKey+".tiers.#").(int); j++ {
+			keys = append(keys, fmt.Sprintf("%s.tiers.%d.amount.0", pricingKey, j))
+		}
+	}
+	if d.Get("payment_preferences.0.setup_fee.#").(int) > 0 {
+		keys = append(keys, "payment_preferences.0.setup_fee.0")
+	}
+	return keys
+}
+
+// statuses List of subscription plan statuses
+func (r SubscriptionPlanResource) statuses() []string {
+	return []string{
+		string(paypalSdk.SubscriptionPlanStatusCreated),
+		string(paypalSdk.SubscriptionPlanStatusActive),
+		string(paypalSdk.SubscriptionPlanStatusInactive),
+	}
+}
+
+// pricingModels List of acceptable pricing models for tiered pricing
+func (r SubscriptionPlanResource) pricingModels() []string {
+	return []string{
+		"volume",
+		"tiered",
+	}
+}
+
 // tenureTypes List of acceptable tenure types
 func (r SubscriptionPlanResource) tenureTypes() []string {
 	return []string{
@@ -469,18 +736,3 @@ func (r SubscriptionPlanResource) setupFeeFailureActions() []string {
 		"cancel",
 	}
 }
-
-// diffSuppressFuncForFloats Function to compare string versions of floats
-func (r SubscriptionPlanResource) diffSuppressFuncForFloats(k, old, new string, d *schema.ResourceData) bool {
-	oldFloat, oldErr := strconv.ParseFloat(old, 64)
-	if oldErr != nil {
-		return false
-	}
-
-	newFloat, newErr := strconv.ParseFloat(new, 64)
-	if newErr != nil {
-		return false
-	}
-
-	return oldFloat == newFloat
-}
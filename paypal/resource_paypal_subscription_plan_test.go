@@ -1,10 +1,13 @@
 package paypal
 
 import (
+	"context"
 	"testing"
 
 	"github.com/go-test/deep"
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/ollieparsley/terraform-provider-paypal/internal/fakepaypal"
 )
 
 func TestSubscriptionPlanResourceSchema(t *testing.T) {
@@ -32,6 +35,21 @@ func TestSubscriptionPlanResourceSchema(t *testing.T) {
 			Optional: true,
 			Required: false,
 		},
+		"environment": {
+			Type:     schema.TypeString,
+			Optional: false,
+			Required: false,
+		},
+		"request_id": {
+			Type:     schema.TypeString,
+			Optional: false,
+			Required: false,
+		},
+		"currency_code": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Required: false,
+		},
 		"quantity_supported": {
 			Type:     schema.TypeBool,
 			Optional: true,
@@ -71,28 +89,28 @@ func TestSubscriptionPlanResourceSchema(t *testing.T) {
 		},
 		"payment_preferences": {
 			Type:     schema.TypeList,
-			Required: true,
-			Optional: false,
+			Required: false,
+			Optional: true,
 			Nested: map[string]SchemaSimplified{
 				"auto_bill_outstanding": {
 					Type:     schema.TypeBool,
-					Required: true,
-					Optional: false,
+					Required: false,
+					Optional: true,
 				},
 				"setup_fee": {
 					Type:     schema.TypeList,
-					Required: true,
-					Optional: false,
+					Required: false,
+					Optional: true,
 				},
 				"payment_failure_threshold": {
 					Type:     schema.TypeInt,
-					Required: true,
-					Optional: false,
+					Required: false,
+					Optional: true,
 				},
 				"setup_fee_failure_action": {
 					Type:     schema.TypeString,
-					Required: true,
-					Optional: false,
+					Required: false,
+					Optional: true,
 				},
 			},
 		},
@@ -194,6 +212,18 @@ func TestSubscriptionPlanPaymentDefinitionFrequencies(t *testing.T) {
 	}
 }
 
+func TestSubscriptionPlanPricingModels(t *testing.T) {
+	resource := SubscriptionPlanResource{}
+	expected := []string{
+		"volume",
+		"tiered",
+	}
+	differences := deep.Equal(expected, resource.pricingModels())
+	if len(differences) > 0 {
+		t.Errorf("Expected didn't match. Got differences: %+v", differences)
+	}
+}
+
 func TestSubscriptionPlanSetupFeeFailureActions(t *testing.T) {
 	resource := SubscriptionPlanResource{}
 	expected := []string{
@@ -205,3 +235,508 @@ func TestSubscriptionPlanSetupFeeFailureActions(t *testing.T) {
 		t.Errorf("Expected didn't match. Got differences: %+v", differences)
 	}
 }
+
+// testSubscriptionPlanConfig A minimal valid subscription plan configuration
+func testSubscriptionPlanConfig() map[string]interface{} {
+	return map[string]interface{}{
+		"product_id":  "PROD-1",
+		"name":        "Plan",
+		"description": "A plan",
+		"taxes": []interface{}{map[string]interface{}{
+			"percentage": "20",
+			"inclusive":  true,
+		}},
+		"payment_preferences": []interface{}{map[string]interface{}{
+			"auto_bill_outstanding":     true,
+			"payment_failure_threshold": 2,
+			"setup_fee_failure_action":  "continue",
+			"setup_fee": []interface{}{map[string]interface{}{
+				"value":         "1.00",
+				"currency_code": "USD",
+			}},
+		}},
+		"billing_cycle": []interface{}{map[string]interface{}{
+			"sequence":     1,
+			"total_cycles": 0,
+			"tenure_type":  "regular",
+			"frequency": []interface{}{map[string]interface{}{
+				"interval_unit":  "month",
+				"interval_count": 1,
+			}},
+			"pricing_scheme": []interface{}{map[string]interface{}{
+				"fixed_price": []interface{}{map[string]interface{}{
+					"value":         "4.99",
+					"currency_code": "USD",
+				}},
+			}},
+		}},
+	}
+}
+
+func TestSubscriptionPlanResourceLifecycle(t *testing.T) {
+	server := fakepaypal.NewServer()
+	defer server.Close()
+	meta := testProviderMeta(t, server)
+
+	resource := SubscriptionPlanResource{}
+	d := schema.TestResourceDataRaw(t, resource.Schema(), testSubscriptionPlanConfig())
+
+	if diags := resource.Create(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("Expected no error creating plan. Got: %+v", diags)
+	}
+	if d.Id() == "" {
+		t.Fatalf("Expected plan ID to be set")
+	}
+	if d.Get("status") != "ACTIVE" {
+		t.Errorf("Expected status to be read back. Got: %v", d.Get("status"))
+	}
+	if d.Get("billing_cycle.0.pricing_scheme.0.fixed_price.0.value") != "4.99" {
+		t.Errorf("Expected billing cycles to be read back. Got: %v", d.Get("billing_cycle"))
+	}
+
+	id := d.Id()
+	if diags := resource.Delete(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("Expected no error deleting plan. Got: %+v", diags)
+	}
+	if status := server.Plan(id)["status"]; status != "INACTIVE" {
+		t.Errorf("Expected plan to be deactivated. Got: %v", status)
+	}
+}
+
+func TestSubscriptionPlanResourceReadRemovesMissingPlan(t *testing.T) {
+	server := fakepaypal.NewServer()
+	defer server.Close()
+	meta := testProviderMeta(t, server)
+
+	resource := SubscriptionPlanResource{}
+	d := schema.TestResourceDataRaw(t, resource.Schema(), testSubscriptionPlanConfig())
+	if diags := resource.Create(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("Expected no error creating plan. Got: %+v", diags)
+	}
+
+	server.DeletePlan(d.Id())
+	if diags := resource.Read(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("Expected no error reading a missing plan. Got: %+v", diags)
+	}
+	if d.Id() != "" {
+		t.Errorf("Expected missing plan to be removed from state. Got ID: %s", d.Id())
+	}
+}
+
+// testTieredPricingScheme A tiered pricing scheme with an open ended last tier
+func testTieredPricingScheme(pricingModel string) map[string]interface{} {
+	return map[string]interface{}{
+		"pricing_model": pricingModel,
+		"tiers": []interface{}{
+			map[string]interface{}{
+				"starting_quantity": "1",
+				"ending_quantity":   "10",
+				"amount": []interface{}{map[string]interface{}{
+					"value":         "5.00",
+					"currency_code": "USD",
+				}},
+			},
+			map[string]interface{}{
+				"starting_quantity": "11",
+				"amount": []interface{}{map[string]interface{}{
+					"value":         "4.00",
+					"currency_code": "USD",
+				}},
+			},
+		},
+	}
+}
+
+func TestSubscriptionPlanResourceTieredPricing(t *testing.T) {
+	server := fakepaypal.NewServer()
+	defer server.Close()
+	meta := testProviderMeta(t, server)
+
+	config := testSubscriptionPlanConfig()
+	config["quantity_supported"] = true
+	config["billing_cycle"].([]interface{})[0].(map[string]interface{})["pricing_scheme"] = []interface{}{testTieredPricingScheme("tiered")}
+
+	resource := SubscriptionPlanResource{}
+	d := schema.TestResourceDataRaw(t, resource.Schema(), config)
+	if diags := resource.Create(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("Expected no error creating plan. Got: %+v", diags)
+	}
+
+	pricingScheme := server.Plan(d.Id())["billing_cycles"].([]interface{})[0].(map[string]interface{})["pricing_scheme"].(map[string]interface{})
+	if pricingScheme["pricing_model"] != "TIERED" || pricingScheme["fixed_price"] != nil {
+		t.Errorf("Expected a tiered pricing scheme to be sent. Got: %+v", pricingScheme)
+	}
+
+	if d.Get("billing_cycle.0.pricing_scheme.0.pricing_model") != "tiered" {
+		t.Errorf("Expected pricing model to be read back. Got: %v", d.Get("billing_cycle.0.pricing_scheme.0.pricing_model"))
+	}
+	if d.Get("billing_cycle.0.pricing_scheme.0.tiers.#") != 2 || d.Get("billing_cycle.0.pricing_scheme.0.fixed_price.#") != 0 {
+		t.Errorf("Expected tiers to be read back. Got: %v", d.Get("billing_cycle.0.pricing_scheme"))
+	}
+	if d.Get("billing_cycle.0.pricing_scheme.0.tiers.0.ending_quantity") != "10" || d.Get("billing_cycle.0.pricing_scheme.0.tiers.1.ending_quantity") != "" {
+		t.Errorf("Expected tier quantities to be read back. Got: %v", d.Get("billing_cycle.0.pricing_scheme.0.tiers"))
+	}
+	if d.Get("billing_cycle.0.pricing_scheme.0.tiers.1.amount.0.value") != "4.00" {
+		t.Errorf("Expected tier amounts to be read back. Got: %v", d.Get("billing_cycle.0.pricing_scheme.0.tiers"))
+	}
+}
+
+func TestSubscriptionPlanResourcePricingSchemeValidation(t *testing.T) {
+	fixedPrice := []interface{}{map[string]interface{}{
+		"value":         "4.99",
+		"currency_code": "USD",
+	}}
+
+	bothPriced := testTieredPricingScheme("volume")
+	bothPriced["fixed_price"] = fixedPrice
+	fixedWithModel := map[string]interface{}{"pricing_model": "volume", "fixed_price": fixedPrice}
+
+	testCases := map[string]struct {
+		pricingScheme map[string]interface{}
+		valid         bool
+	}{
+		"fixed price":              {map[string]interface{}{"fixed_price": fixedPrice}, true},
+		"volume tiers":             {testTieredPricingScheme("volume"), true},
+		"fixed price and tiers":    {bothPriced, false},
+		"no price":                 {map[string]interface{}{}, false},
+		"tiers without model":      {testTieredPricingScheme(""), false},
+		"fixed price with a model": {fixedWithModel, false},
+	}
+
+	resource := SubscriptionPlanResource{}.Resource()
+	for name, testCase := range testCases {
+		config := testSubscriptionPlanConfig()
+		config["billing_cycle"].([]interface{})[0].(map[string]interface{})["pricing_scheme"] = []interface{}{testCase.pricingScheme}
+
+		_, err := resource.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), nil)
+		if testCase.valid && err != nil {
+			t.Errorf("%s: Expected no error. Got: %s", name, err)
+		}
+		if !testCase.valid && err == nil {
+			t.Errorf("%s: Expected an error", name)
+		}
+	}
+}
+
+// testResourceDataUpdate Plan a change from a resource's current state to a new configuration,
+// returning resource data ready to pass to Update
+func testResourceDataUpdate(t *testing.T, resource *schema.Resource, d *schema.ResourceData, config map[string]interface{}) *schema.ResourceData {
+	t.Helper()
+
+	state := d.State()
+	diff, err := resource.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), nil)
+	if err != nil {
+		t.Fatalf("Expected no error planning the update. Got: %s", err)
+	}
+	data, err := schema.InternalMap(resource.Schema).Data(state, diff)
+	if err != nil {
+		t.Fatalf("Expected no error applying the planned update. Got: %s", err)
+	}
+	return data
+}
+
+func TestSubscriptionPlanResourceStatus(t *testing.T) {
+	server := fakepaypal.NewServer()
+	defer server.Close()
+	meta := testProviderMeta(t, server)
+
+	resource := SubscriptionPlanResource{}
+	config := testSubscriptionPlanConfig()
+	config["status"] = "INACTIVE"
+	d := schema.TestResourceDataRaw(t, resource.Schema(), config)
+	if diags := resource.Create(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("Expected no error creating plan. Got: %+v", diags)
+	}
+	if status := server.Plan(d.Id())["status"]; status != "INACTIVE" || d.Get("status") != "INACTIVE" {
+		t.Errorf("Expected plan to be created inactive. Got: %v", status)
+	}
+
+	config["status"] = "ACTIVE"
+	d = testResourceDataUpdate(t, resource.Resource(), d, config)
+	if diags := resource.Update(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("Expected no error activating plan. Got: %+v", diags)
+	}
+	if status := server.Plan(d.Id())["status"]; status != "ACTIVE" {
+		t.Errorf("Expected plan to be activated. Got: %v", status)
+	}
+
+	config["status"] = "INACTIVE"
+	d = testResourceDataUpdate(t, resource.Resource(), d, config)
+	if diags := resource.Update(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("Expected no error deactivating plan. Got: %+v", diags)
+	}
+	if status := server.Plan(d.Id())["status"]; status != "INACTIVE" {
+		t.Errorf("Expected plan to be deactivated. Got: %v", status)
+	}
+
+	// Inactive plans are left as they are on delete
+	if diags := resource.Delete(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("Expected no error deleting an inactive plan. Got: %+v", diags)
+	}
+}
+
+func TestSubscriptionPlanResourceCreatedStatus(t *testing.T) {
+	server := fakepaypal.NewServer()
+	defer server.Close()
+	meta := testProviderMeta(t, server)
+
+	resource := SubscriptionPlanResource{}
+	config := testSubscriptionPlanConfig()
+	config["status"] = "CREATED"
+	d := schema.TestResourceDataRaw(t, resource.Schema(), config)
+	if diags := resource.Create(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("Expected no error creating plan. Got: %+v", diags)
+	}
+	if status := server.Plan(d.Id())["status"]; status != "CREATED" {
+		t.Errorf("Expected plan to be created without activating. Got: %v", status)
+	}
+}
+
+func TestSubscriptionPlanResourceStatusValidation(t *testing.T) {
+	resource := SubscriptionPlanResource{}.Resource()
+
+	config := testSubscriptionPlanConfig()
+	config["status"] = "active"
+	if diags := resource.Validate(terraform.NewResourceConfigRaw(config)); !diags.HasError() {
+		t.Errorf("Expected an error for a lower case status")
+	}
+
+	state := &terraform.InstanceState{
+		ID:         "P-1",
+		Attributes: map[string]string{"status": "ACTIVE"},
+	}
+	config["status"] = "CREATED"
+	if _, err := resource.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), nil); err == nil {
+		t.Errorf("Expected an error moving an active plan back to CREATED")
+	}
+}
+
+// testBillingCycle A monthly fixed price billing cycle
+func testBillingCycle(sequence int, tenureType string, totalCycles int) map[string]interface{} {
+	return map[string]interface{}{
+		"sequence":     sequence,
+		"total_cycles": totalCycles,
+		"tenure_type":  tenureType,
+		"frequency": []interface{}{map[string]interface{}{
+			"interval_unit":  "month",
+			"interval_count": 1,
+		}},
+		"pricing_scheme": []interface{}{map[string]interface{}{
+			"fixed_price": []interface{}{map[string]interface{}{
+				"value":         "4.99",
+				"currency_code": "USD",
+			}},
+		}},
+	}
+}
+
+func TestSubscriptionPlanResourceBillingCycleValidation(t *testing.T) {
+	testCases := map[string]struct {
+		billingCycles []interface{}
+		valid         bool
+	}{
+		"regular only": {
+			[]interface{}{testBillingCycle(1, "regular", 0)},
+			true,
+		},
+		"trials before regular": {
+			[]interface{}{testBillingCycle(1, "trial", 1), testBillingCycle(2, "trial", 3), testBillingCycle(3, "regular", 12)},
+			true,
+		},
+		"trial after regular": {
+			[]interface{}{testBillingCycle(2, "trial", 1), testBillingCycle(1, "regular", 0)},
+			false,
+		},
+		"duplicate sequences": {
+			[]interface{}{testBillingCycle(1, "trial", 1), testBillingCycle(1, "regular", 0)},
+			false,
+		},
+		"two regular cycles": {
+			[]interface{}{testBillingCycle(1, "regular", 12), testBillingCycle(2, "regular", 0)},
+			false,
+		},
+		"infinite trial": {
+			[]interface{}{testBillingCycle(1, "trial", 0), testBillingCycle(2, "regular", 0)},
+			false,
+		},
+	}
+
+	resource := SubscriptionPlanResource{}.Resource()
+	for name, testCase := range testCases {
+		config := testSubscriptionPlanConfig()
+		config["billing_cycle"] = testCase.billingCycles
+
+		_, err := resource.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), nil)
+		if testCase.valid && err != nil {
+			t.Errorf("%s: Expected no error. Got: %s", name, err)
+		}
+		if !testCase.valid && err == nil {
+			t.Errorf("%s: Expected an error", name)
+		}
+	}
+}
+
+func TestSubscriptionPlanResourceMoneyValidation(t *testing.T) {
+	resource := SubscriptionPlanResource{}.Resource()
+
+	setPrice := func(config map[string]interface{}, value, currencyCode string) {
+		pricingScheme := config["billing_cycle"].([]interface{})[0].(map[string]interface{})["pricing_scheme"].([]interface{})[0].(map[string]interface{})
+		pricingScheme["fixed_price"] = []interface{}{map[string]interface{}{
+			"value":         value,
+			"currency_code": currencyCode,
+		}}
+	}
+
+	config := testSubscriptionPlanConfig()
+	setPrice(config, "4.99", "XYZ")
+	if diags := resource.Validate(terraform.NewResourceConfigRaw(config)); !diags.HasError() {
+		t.Errorf("Expected an error for an unsupported currency")
+	}
+
+	config = testSubscriptionPlanConfig()
+	setPrice(config, "$4.99", "USD")
+	if diags := resource.Validate(terraform.NewResourceConfigRaw(config)); !diags.HasError() {
+		t.Errorf("Expected an error for a value that is not a decimal")
+	}
+
+	config = testSubscriptionPlanConfig()
+	setPrice(config, "500.50", "JPY")
+	if _, err := resource.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), nil); err == nil {
+		t.Errorf("Expected an error for decimals in a JPY price")
+	}
+
+	config = testSubscriptionPlanConfig()
+	config["payment_preferences"].([]interface{})[0].(map[string]interface{})["setup_fee"] = []interface{}{map[string]interface{}{
+		"value":         "1.005",
+		"currency_code": "USD",
+	}}
+	if _, err := resource.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), nil); err == nil {
+		t.Errorf("Expected an error for three decimal places in a USD setup fee")
+	}
+
+	config = testSubscriptionPlanConfig()
+	setPrice(config, "500", "JPY")
+	config["payment_preferences"].([]interface{})[0].(map[string]interface{})["setup_fee"] = []interface{}{map[string]interface{}{
+		"value":         "100",
+		"currency_code": "JPY",
+	}}
+	if _, err := resource.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), nil); err != nil {
+		t.Errorf("Expected no error for whole JPY amounts. Got: %s", err)
+	}
+}
+
+func TestSubscriptionPlanResourceCurrencyValidation(t *testing.T) {
+	resource := SubscriptionPlanResource{}.Resource()
+
+	setupFee := func(config map[string]interface{}) map[string]interface{} {
+		return config["payment_preferences"].([]interface{})[0].(map[string]interface{})["setup_fee"].([]interface{})[0].(map[string]interface{})
+	}
+	fixedPrice := func(config map[string]interface{}) map[string]interface{} {
+		return config["billing_cycle"].([]interface{})[0].(map[string]interface{})["pricing_scheme"].([]interface{})[0].(map[string]interface{})["fixed_price"].([]interface{})[0].(map[string]interface{})
+	}
+
+	config := testSubscriptionPlanConfig()
+	setupFee(config)["currency_code"] = "EUR"
+	if _, err := resource.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), nil); err == nil {
+		t.Errorf("Expected an error for a setup fee in a different currency")
+	}
+
+	config = testSubscriptionPlanConfig()
+	config["currency_code"] = "EUR"
+	if _, err := resource.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), nil); err == nil {
+		t.Errorf("Expected an error for prices overriding the plan currency with another")
+	}
+
+	config = testSubscriptionPlanConfig()
+	delete(setupFee(config), "currency_code")
+	delete(fixedPrice(config), "currency_code")
+	if _, err := resource.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), nil); err == nil {
+		t.Errorf("Expected an error for prices without a currency")
+	}
+
+	config["currency_code"] = "GBP"
+	if _, err := resource.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), nil); err != nil {
+		t.Errorf("Expected no error for prices defaulting to the plan currency. Got: %s", err)
+	}
+}
+
+func TestSubscriptionPlanResourceDefaultCurrency(t *testing.T) {
+	server := fakepaypal.NewServer()
+	defer server.Close()
+	meta := testProviderMeta(t, server)
+
+	config := testSubscriptionPlanConfig()
+	config["currency_code"] = "GBP"
+	delete(config["payment_preferences"].([]interface{})[0].(map[string]interface{})["setup_fee"].([]interface{})[0].(map[string]interface{}), "currency_code")
+	config["billing_cycle"].([]interface{})[0].(map[string]interface{})["pricing_scheme"] = []interface{}{testTieredPricingScheme("volume")}
+	for _, tier := range config["billing_cycle"].([]interface{})[0].(map[string]interface{})["pricing_scheme"].([]interface{})[0].(map[string]interface{})["tiers"].([]interface{}) {
+		delete(tier.(map[string]interface{})["amount"].([]interface{})[0].(map[string]interface{}), "currency_code")
+	}
+
+	resource := SubscriptionPlanResource{}
+	d := schema.TestResourceDataRaw(t, resource.Schema(), config)
+	if diags := resource.Create(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("Expected no error creating plan. Got: %+v", diags)
+	}
+
+	if currencyCode := d.Get("payment_preferences.0.setup_fee.0.currency_code"); currencyCode != "GBP" {
+		t.Errorf("Expected setup fee to default to the plan currency. Got: %v", currencyCode)
+	}
+	if currencyCode := d.Get("billing_cycle.0.pricing_scheme.0.tiers.1.amount.0.currency_code"); currencyCode != "GBP" {
+		t.Errorf("Expected tier amounts to default to the plan currency. Got: %v", currencyCode)
+	}
+
+	diff, err := resource.Resource().Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(config), meta)
+	if err != nil {
+		t.Fatalf("Expected no error planning an unchanged plan. Got: %s", err)
+	}
+	if diff != nil && !diff.Empty() {
+		t.Errorf("Expected defaulted currencies not to show a diff. Got: %+v", diff.Attributes)
+	}
+}
+
+func TestSubscriptionPlanResourceDefaultPaymentPreferences(t *testing.T) {
+	server := fakepaypal.NewServer()
+	defer server.Close()
+	meta := testProviderMeta(t, server)
+
+	config := testSubscriptionPlanConfig()
+	delete(config, "payment_preferences")
+
+	resource := SubscriptionPlanResource{}
+	d := schema.TestResourceDataRaw(t, resource.Schema(), config)
+	if diags := resource.Create(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("Expected no error creating plan. Got: %+v", diags)
+	}
+
+	if d.Get("payment_preferences.0.auto_bill_outstanding") != true || d.Get("payment_preferences.0.setup_fee_failure_action") != "cancel" {
+		t.Errorf("Expected PayPal's payment preferences to be read back. Got: %v", d.Get("payment_preferences"))
+	}
+	if d.Get("payment_preferences.0.setup_fee.#") != 0 {
+		t.Errorf("Expected no setup fee. Got: %v", d.Get("payment_preferences.0.setup_fee"))
+	}
+
+	diff, err := resource.Resource().Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(config), meta)
+	if err != nil {
+		t.Fatalf("Expected no error planning an unchanged plan. Got: %s", err)
+	}
+	if diff != nil && !diff.Empty() {
+		t.Errorf("Expected PayPal's payment preferences not to show a diff. Got: %+v", diff.Attributes)
+	}
+
+	// A setup fee can be left out while setting other preferences
+	config["payment_preferences"] = []interface{}{map[string]interface{}{
+		"payment_failure_threshold": 3,
+	}}
+	d = schema.TestResourceDataRaw(t, resource.Schema(), config)
+	if diags := resource.Create(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("Expected no error creating plan. Got: %+v", diags)
+	}
+	preferences := server.Plan(d.Id())["payment_preferences"].(map[string]interface{})
+	if _, ok := preferences["setup_fee"]; ok {
+		t.Errorf("Expected the setup fee to be left out. Got: %+v", preferences)
+	}
+	if preferences["payment_failure_threshold"] != 3.0 || preferences["setup_fee_failure_action"] != "CANCEL" {
+		t.Errorf("Expected unset preferences to default. Got: %+v", preferences)
+	}
+}
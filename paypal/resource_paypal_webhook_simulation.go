@@ -0,0 +1,149 @@
+package paypal
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// WebhookSimulationResource Sends a sample event to a webhook when created, to check the receiving endpoint works.
+// PayPal does not keep simulated events, so there is nothing to read back or delete
+// Doc: https://developer.paypal.com/docs/api/webhooks/v1/#simulate-event_post
+type WebhookSimulationResource struct{}
+
+func (r WebhookSimulationResource) Resource() *schema.Resource {
+	return &schema.Resource{
+		Schema:        r.Schema(),
+		CreateContext: r.Create,
+		ReadContext:   r.Read,
+		DeleteContext: r.Delete,
+		CustomizeDiff: customdiff.All(
+			customizeDiffEnvironment,
+			r.customizeDiffEventType,
+		),
+	}
+}
+
+func (r WebhookSimulationResource) Schema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"webhook_id": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			ExactlyOneOf: []string{"webhook_id", "url"},
+			Description:  "The ID of the webhook to send the event to. Either webhook_id or url must be set",
+		},
+		"url": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			ExactlyOneOf: []string{"webhook_id", "url"},
+			Description:  "The URL to send the event to. Either webhook_id or url must be set",
+		},
+		"event_type": {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+			ValidateDiagFunc: func(v interface{}, path cty.Path) diag.Diagnostics {
+				return append(
+					validateWebhookEventType(v, path),
+					validation.ToDiagFunc(validation.StringNotInSlice([]string{webhookEventTypeAll}, false))(v, path)...,
+				)
+			},
+			StateFunc: func(v interface{}) string {
+				return strings.ToUpper(v.(string))
+			},
+			Description: "The event type to simulate, such as PAYMENT.SALE.COMPLETED. Names are not case sensitive",
+		},
+		"resource_version": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			ForceNew:    true,
+			Description: "The version of the event's resource to send, such as 1.0 or 2.0. Defaults to PayPal's default for the event type",
+		},
+		"triggers": {
+			Type:        schema.TypeMap,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Optional:    true,
+			ForceNew:    true,
+			Description: "Arbitrary values that send a new event whenever they change",
+		},
+		"event_id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The ID of the simulated event",
+		},
+		"create_time": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The date and time the event was sent, in RFC 3339 format",
+		},
+		"environment": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The PayPal environment the event was sent from: sandbox, live or custom",
+		},
+	}
+}
+
+// Create - Send a simulated webhook event from Paypal
+func (r WebhookSimulationResource) Create(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*ProviderMeta).Client
+
+	event, err := client.SimulateWebhookEvent(ctx, SimulateWebhookEventRequest{
+		WebhookID:       d.Get("webhook_id").(string),
+		URL:             d.Get("url").(string),
+		EventType:       strings.ToUpper(d.Get("event_type").(string)),
+		ResourceVersion: d.Get("resource_version").(string),
+	})
+	if err != nil {
+		log.Printf("Error simulating webhook event: %s", err.Error())
+		return diag.FromErr(err)
+	}
+
+	d.SetId(event.ID)
+	d.Set("event_id", event.ID)
+	d.Set("create_time", event.CreateTime.Format(time.RFC3339))
+	d.Set("environment", m.(*ProviderMeta).Environment)
+
+	log.Printf("Simulated webhook event %s with ID: %s", event.EventType, event.ID)
+
+	return r.Read(ctx, d, m)
+}
+
+// Read - Simulated events are not stored by Paypal, so the state is kept as created
+func (r WebhookSimulationResource) Read(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return nil
+}
+
+// Update - Every argument forces a new event, so there is nothing to update
+func (r WebhookSimulationResource) Update(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return r.Read(ctx, d, m)
+}
+
+// Delete - Remove the simulated event from state. Events that have been sent cannot be recalled
+func (r WebhookSimulationResource) Delete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	d.SetId("")
+
+	return nil
+}
+
+// customizeDiffEventType Check the event type is one PayPal can send when a new event is planned
+func (r WebhookSimulationResource) customizeDiffEventType(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("event_type") || !d.HasChange("event_type") {
+		return nil
+	}
+
+	var client PaypalClient
+	if meta, ok := m.(*ProviderMeta); ok {
+		client = meta.Client
+	}
+	return checkWebhookEventTypes(ctx, client, "event_type", []string{d.Get("event_type").(string)})
+}
@@ -0,0 +1,120 @@
+package paypal
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/ollieparsley/terraform-provider-paypal/internal/fakepaypal"
+)
+
+func TestWebhookSimulationResourceValidation(t *testing.T) {
+	resource := WebhookSimulationResource{}.Resource()
+
+	testCases := []struct {
+		config map[string]interface{}
+		valid  bool
+	}{
+		{map[string]interface{}{"webhook_id": "WH-1", "event_type": "payment.sale.completed"}, true},
+		{map[string]interface{}{"url": "https://example.com/hook", "event_type": "PAYMENT.SALE.COMPLETED"}, true},
+		{map[string]interface{}{"event_type": "PAYMENT.SALE.COMPLETED"}, false},
+		{map[string]interface{}{"webhook_id": "WH-1", "url": "https://example.com/hook", "event_type": "PAYMENT.SALE.COMPLETED"}, false},
+		{map[string]interface{}{"webhook_id": "WH-1", "event_type": "PAYMENT.SALE.*"}, false},
+		{map[string]interface{}{"webhook_id": "WH-1", "event_type": "*"}, false},
+	}
+	for _, testCase := range testCases {
+		diags := resource.Validate(terraform.NewResourceConfigRaw(testCase.config))
+		if testCase.valid && diags.HasError() {
+			t.Errorf("Expected %+v to be valid. Got: %+v", testCase.config, diags)
+		}
+		if !testCase.valid && !diags.HasError() {
+			t.Errorf("Expected %+v to be invalid", testCase.config)
+		}
+	}
+
+	server := fakepaypal.NewServer()
+	defer server.Close()
+	meta := testProviderMeta(t, server)
+
+	typo := terraform.NewResourceConfigRaw(map[string]interface{}{"webhook_id": "WH-1", "event_type": "PAYMENT.SALE.COMPLETD"})
+	if _, err := resource.Diff(context.Background(), nil, typo, meta); err == nil {
+		t.Errorf("Expected an error planning an unknown event type")
+	}
+}
+
+func TestWebhookSimulationResourceLifecycle(t *testing.T) {
+	server := fakepaypal.NewServer()
+	defer server.Close()
+	meta := testProviderMeta(t, server)
+	webhookID := testCreateWebhook(t, meta, "https://example.com/hook", "PAYMENT.SALE.COMPLETED")
+
+	resource := WebhookSimulationResource{}
+	config := map[string]interface{}{
+		"webhook_id": webhookID,
+		"event_type": "payment.sale.completed",
+		"triggers":   map[string]interface{}{"deployment": "1"},
+	}
+	d := schema.TestResourceDataRaw(t, resource.Schema(), config)
+
+	if diags := resource.Create(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("Expected no error simulating event. Got: %+v", diags)
+	}
+	if d.Id() == "" || d.Get("event_id") != d.Id() {
+		t.Fatalf("Expected the event ID to be recorded. Got ID %q and event_id %q", d.Id(), d.Get("event_id"))
+	}
+	if d.Get("create_time") == "" {
+		t.Errorf("Expected the event create time to be recorded")
+	}
+
+	event := server.Event(d.Id())
+	if event == nil {
+		t.Fatalf("Expected the event to be sent")
+	}
+	if event["webhook_id"] != webhookID || event["event_type"] != "PAYMENT.SALE.COMPLETED" {
+		t.Errorf("Expected the event to be sent to the webhook. Got: %+v", event)
+	}
+
+	// Re-planning with the same triggers does not send another event
+	diff, err := resource.Resource().Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(config), meta)
+	if err != nil {
+		t.Fatalf("Expected no error planning an unchanged simulation. Got: %s", err)
+	}
+	if diff != nil && !diff.Empty() {
+		t.Errorf("Expected an unchanged simulation not to show a diff. Got: %+v", diff.Attributes)
+	}
+
+	config["triggers"] = map[string]interface{}{"deployment": "2"}
+	diff, err = resource.Resource().Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(config), meta)
+	if err != nil {
+		t.Fatalf("Expected no error planning changed triggers. Got: %s", err)
+	}
+	if diff == nil || !diff.RequiresNew() {
+		t.Errorf("Expected changed triggers to send a new event")
+	}
+
+	if diags := resource.Delete(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("Expected no error deleting simulation. Got: %+v", diags)
+	}
+	if d.Id() != "" {
+		t.Errorf("Expected simulation to be removed from state")
+	}
+}
+
+func TestWebhookSimulationResourceUnknownWebhook(t *testing.T) {
+	server := fakepaypal.NewServer()
+	defer server.Close()
+	meta := testProviderMeta(t, server)
+
+	resource := WebhookSimulationResource{}
+	d := schema.TestResourceDataRaw(t, resource.Schema(), map[string]interface{}{
+		"webhook_id": "WH-MISSING",
+		"event_type": "PAYMENT.SALE.COMPLETED",
+	})
+	if diags := resource.Create(context.Background(), d, meta); !diags.HasError() {
+		t.Errorf("Expected an error simulating an event for an unknown webhook")
+	}
+	if d.Id() != "" {
+		t.Errorf("Expected no simulation to be stored. Got ID: %s", d.Id())
+	}
+}
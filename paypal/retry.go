@@ -0,0 +1,112 @@
+package paypal
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// retryTransport Retries requests PayPal rejected with a rate limit or a server error,
+// backing off exponentially or for as long as a Retry-After header asks. Requests sent with
+// a PayPal-Request-Id are also retried when they fail to get a response, as PayPal will not repeat them
+type retryTransport struct {
+	transport  http.RoundTripper
+	maxRetries int
+	minWait    time.Duration
+	maxWait    time.Duration
+}
+
+// RoundTrip Send the request, retrying transient failures
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		// Each retry sends a copy of the request with a fresh body, as the request must not be modified
+		attemptReq := req
+		if attempt > 0 && req.Body != nil {
+			if req.GetBody == nil {
+				return nil, errRetryBody
+			}
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attemptReq = req.Clone(req.Context())
+			attemptReq.Body = body
+		}
+
+		resp, err := t.transport.RoundTrip(attemptReq)
+		if attempt >= t.maxRetries || !isRetryable(req, resp, err) {
+			return resp, err
+		}
+
+		var wait time.Duration
+		if err != nil {
+			wait = t.wait(attempt, "")
+			log.Printf("[WARN] Sending %s %s to PayPal failed: %s, retrying in %s (attempt %d of %d)", req.Method, req.URL.Path, err, wait, attempt+1, t.maxRetries)
+		} else {
+			wait = t.wait(attempt, resp.Header.Get("Retry-After"))
+			log.Printf("[WARN] PayPal responded to %s %s with %d, retrying in %s (attempt %d of %d)", req.Method, req.URL.Path, resp.StatusCode, wait, attempt+1, t.maxRetries)
+
+			// Drain the body so the connection can be reused
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// wait How long to wait before the next attempt, capped at the maximum wait
+func (t *retryTransport) wait(attempt int, retryAfter string) time.Duration {
+	wait, ok := parseRetryAfter(retryAfter, time.Now())
+	if !ok {
+		wait = t.minWait << uint(attempt)
+	}
+	if wait > t.maxWait || wait < 0 {
+		wait = t.maxWait
+	}
+	return wait
+}
+
+// isRetryable Whether a failed attempt is worth retrying. A request that got no response may have been
+// handled, so it is only retried when its PayPal-Request-Id stops PayPal handling it twice
+func isRetryable(req *http.Request, resp *http.Response, err error) bool {
+	if err != nil {
+		return req.Header.Get(requestIDHeader) != "" && req.Context().Err() == nil
+	}
+	return isRetryableStatus(resp.StatusCode)
+}
+
+// isRetryableStatus Rate limits and server errors are worth retrying
+func isRetryableStatus(status int) bool {
+	return status == http.StatusTooManyRequests || status >= http.StatusInternalServerError
+}
+
+// parseRetryAfter Parse a Retry-After header given either as seconds or as an HTTP date
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if wait := date.Sub(now); wait > 0 {
+			return wait, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// errRetryBody A request body can only be resent if it can be recreated
+var errRetryBody = errors.New("cannot retry a request whose body cannot be recreated")
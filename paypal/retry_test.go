@@ -0,0 +1,195 @@
+package paypal
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ollieparsley/terraform-provider-paypal/internal/fakepaypal"
+	paypalSdk "github.com/plutov/paypal/v4"
+)
+
+// testRetryClient An authenticated SDK client against the fake API that retries quickly
+func testRetryClient(t *testing.T, server *fakepaypal.Server, maxRetries int) *paypalSdk.Client {
+	t.Helper()
+
+	config := Config{
+		ClientID:     fakepaypal.ClientID,
+		ClientSecret: fakepaypal.ClientSecret,
+		BaseURL:      server.URL,
+		MaxRetries:   maxRetries,
+		RetryMinWait: time.Millisecond,
+		RetryMaxWait: 10 * time.Millisecond,
+	}
+	client, err := config.Client()
+	if err != nil {
+		t.Fatalf("Expected no error creating client. Got: %s", err)
+	}
+	if _, err := client.GetAccessToken(context.Background()); err != nil {
+		t.Fatalf("Expected no error getting access token. Got: %s", err)
+	}
+	return client
+}
+
+func TestRetryTransportRetriesServerErrors(t *testing.T) {
+	server := fakepaypal.NewServer()
+	defer server.Close()
+	client := testRetryClient(t, server, 3)
+
+	server.FailNext(2, http.StatusServiceUnavailable, "")
+	product, err := client.CreateProduct(context.Background(), paypalSdk.Product{
+		Name: "Retried product",
+		Type: paypalSdk.ProductTypeService,
+	})
+	if err != nil {
+		t.Fatalf("Expected the request to succeed after retrying. Got: %s", err)
+	}
+	if product.Name != "Retried product" {
+		t.Errorf("Expected the request body to be resent. Got: %+v", product)
+	}
+
+	// Token request and three attempts at creating the product
+	if requests := server.Requests(); len(requests) != 4 {
+		t.Errorf("Expected 4 requests. Got: %d", len(requests))
+	}
+}
+
+func TestRetryTransportRetriesRateLimits(t *testing.T) {
+	server := fakepaypal.NewServer()
+	defer server.Close()
+	client := testRetryClient(t, server, 1)
+
+	server.FailNext(1, http.StatusTooManyRequests, "0")
+	if _, err := client.ListWebhooks(context.Background(), ""); err != nil {
+		t.Fatalf("Expected the request to succeed after retrying. Got: %s", err)
+	}
+}
+
+func TestRetryTransportGivesUp(t *testing.T) {
+	server := fakepaypal.NewServer()
+	defer server.Close()
+	client := testRetryClient(t, server, 2)
+
+	server.FailNext(3, http.StatusInternalServerError, "")
+	_, err := client.GetProduct(context.Background(), "PROD-1")
+
+	var errorResponse *paypalSdk.ErrorResponse
+	if !errors.As(err, &errorResponse) || errorResponse.Response.StatusCode != http.StatusInternalServerError {
+		t.Errorf("Expected the last server error to be returned. Got: %v", err)
+	}
+}
+
+func TestRetryTransportDoesNotRetryClientErrors(t *testing.T) {
+	server := fakepaypal.NewServer()
+	defer server.Close()
+	client := testRetryClient(t, server, 3)
+
+	if _, err := client.GetProduct(context.Background(), "PROD-MISSING"); !isNotFound(err) {
+		t.Errorf("Expected a not found error. Got: %v", err)
+	}
+	if requests := server.Requests(); len(requests) != 2 {
+		t.Errorf("Expected the not found response not to be retried. Got %d requests", len(requests))
+	}
+}
+
+// flakyTransport Fails the first requests without a response, then responds with no content
+type flakyTransport struct {
+	failures int
+	attempts int
+}
+
+func (t *flakyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.attempts++
+	if t.attempts <= t.failures {
+		return nil, errors.New("connection reset by peer")
+	}
+	return &http.Response{StatusCode: http.StatusNoContent, Body: http.NoBody, Request: req}, nil
+}
+
+func TestRetryTransportRetriesRequestIDsWithoutResponse(t *testing.T) {
+	for _, withRequestID := range []bool{true, false} {
+		flaky := &flakyTransport{failures: 1}
+		transport := &retryTransport{transport: flaky, maxRetries: 2, minWait: time.Millisecond, maxWait: time.Millisecond}
+
+		req, _ := http.NewRequest(http.MethodPost, "https://example.com/v1/catalogs/products", nil)
+		if withRequestID {
+			req.Header.Set(requestIDHeader, "request-1")
+		}
+		_, err := transport.RoundTrip(req)
+
+		if withRequestID && (err != nil || flaky.attempts != 2) {
+			t.Errorf("Expected a request with a request ID to be retried. Got %d attempts and error: %v", flaky.attempts, err)
+		}
+		if !withRequestID && (err == nil || flaky.attempts != 1) {
+			t.Errorf("Expected a request without a request ID not to be retried. Got %d attempts and error: %v", flaky.attempts, err)
+		}
+	}
+}
+
+func TestRetryTransportDoesNotModifyRequest(t *testing.T) {
+	flaky := &flakyTransport{failures: 1}
+	transport := &retryTransport{transport: flaky, maxRetries: 2, minWait: time.Millisecond, maxWait: time.Millisecond}
+
+	req, _ := http.NewRequest(http.MethodPost, "https://example.com/v1/catalogs/products", strings.NewReader("{}"))
+	req.Header.Set(requestIDHeader, "request-1")
+	body := req.Body
+	if _, err := transport.RoundTrip(req); err != nil || flaky.attempts != 2 {
+		t.Fatalf("Expected the request to be retried. Got %d attempts and error: %v", flaky.attempts, err)
+	}
+	if req.Body != body {
+		t.Errorf("Expected the retry to send a copy of the request instead of replacing its body")
+	}
+}
+
+func TestRetryTransportWait(t *testing.T) {
+	transport := &retryTransport{minWait: time.Second, maxWait: 5 * time.Second}
+
+	cases := []struct {
+		attempt    int
+		retryAfter string
+		expected   time.Duration
+	}{
+		{attempt: 0, expected: time.Second},
+		{attempt: 1, expected: 2 * time.Second},
+		{attempt: 2, expected: 4 * time.Second},
+		{attempt: 3, expected: 5 * time.Second},
+		{attempt: 0, retryAfter: "3", expected: 3 * time.Second},
+		{attempt: 0, retryAfter: "120", expected: 5 * time.Second},
+		{attempt: 1, retryAfter: "soon", expected: 2 * time.Second},
+	}
+
+	for _, c := range cases {
+		if actual := transport.wait(c.attempt, c.retryAfter); actual != c.expected {
+			t.Errorf("Attempt %d with Retry-After %q: expected %s. Got: %s", c.attempt, c.retryAfter, c.expected, actual)
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2021, 11, 1, 12, 0, 0, 0, time.UTC)
+
+	wait, ok := parseRetryAfter("7", now)
+	if !ok || wait != 7*time.Second {
+		t.Errorf("Expected seconds to be parsed. Got: %s %t", wait, ok)
+	}
+
+	wait, ok = parseRetryAfter("Mon, 01 Nov 2021 12:00:10 GMT", now)
+	if !ok || wait != 10*time.Second {
+		t.Errorf("Expected HTTP date to be parsed. Got: %s %t", wait, ok)
+	}
+
+	wait, ok = parseRetryAfter("Mon, 01 Nov 2021 11:00:00 GMT", now)
+	if !ok || wait != 0 {
+		t.Errorf("Expected a past HTTP date to mean no wait. Got: %s %t", wait, ok)
+	}
+
+	if _, ok := parseRetryAfter("", now); ok {
+		t.Errorf("Expected an empty header not to be parsed")
+	}
+	if _, ok := parseRetryAfter("-1", now); ok {
+		t.Errorf("Expected negative seconds not to be parsed")
+	}
+}
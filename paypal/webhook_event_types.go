@@ -0,0 +1,165 @@
+package paypal
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// webhookEventTypeAll The event type that subscribes a webhook to every event
+const webhookEventTypeAll = "*"
+
+// webhookEventTypes The webhook event names PayPal documents, used to catch typos at plan time.
+// Use the paypal_webhook_event_types data source for the live list.
+// Doc: https://developer.paypal.com/docs/api-basics/notifications/webhooks/event-names/
+var webhookEventTypes = []string{
+	// Billing plans and subscriptions
+	"BILLING.PLAN.ACTIVATED",
+	"BILLING.PLAN.CREATED",
+	"BILLING.PLAN.DEACTIVATED",
+	"BILLING.PLAN.PRICING-CHANGE.ACTIVATED",
+	"BILLING.PLAN.PRICING-CHANGE.INPROGRESS",
+	"BILLING.PLAN.UPDATED",
+	"BILLING.SUBSCRIPTION.ACTIVATED",
+	"BILLING.SUBSCRIPTION.CANCELLED",
+	"BILLING.SUBSCRIPTION.CREATED",
+	"BILLING.SUBSCRIPTION.EXPIRED",
+	"BILLING.SUBSCRIPTION.PAYMENT.FAILED",
+	"BILLING.SUBSCRIPTION.RE-ACTIVATED",
+	"BILLING.SUBSCRIPTION.RENEWED",
+	"BILLING.SUBSCRIPTION.SUSPENDED",
+	"BILLING.SUBSCRIPTION.UPDATED",
+	"BILLING_AGREEMENTS.AGREEMENT.CANCELLED",
+	"BILLING_AGREEMENTS.AGREEMENT.CREATED",
+
+	// Catalog products
+	"CATALOG.PRODUCT.CREATED",
+	"CATALOG.PRODUCT.UPDATED",
+
+	// Orders
+	"CHECKOUT.ORDER.APPROVED",
+	"CHECKOUT.ORDER.COMPLETED",
+	"CHECKOUT.ORDER.PROCESSED",
+	"CHECKOUT.ORDER.SAVED",
+	"CHECKOUT.ORDER.VOIDED",
+	"CHECKOUT.PAYMENT-APPROVAL.REVERSED",
+
+	// Disputes
+	"CUSTOMER.DISPUTE.CREATED",
+	"CUSTOMER.DISPUTE.RESOLVED",
+	"CUSTOMER.DISPUTE.UPDATED",
+	"RISK.DISPUTE.CREATED",
+
+	// Identity and merchant onboarding
+	"IDENTITY.AUTHORIZATION-CONSENT.REVOKED",
+	"MERCHANT.ONBOARDING.COMPLETED",
+	"MERCHANT.PARTNER-CONSENT.REVOKED",
+
+	// Invoicing
+	"INVOICING.INVOICE.CANCELLED",
+	"INVOICING.INVOICE.CREATED",
+	"INVOICING.INVOICE.PAID",
+	"INVOICING.INVOICE.REFUNDED",
+	"INVOICING.INVOICE.SCHEDULED",
+	"INVOICING.INVOICE.UPDATED",
+
+	// Payments
+	"PAYMENT.AUTHORIZATION.CREATED",
+	"PAYMENT.AUTHORIZATION.VOIDED",
+	"PAYMENT.CAPTURE.COMPLETED",
+	"PAYMENT.CAPTURE.DENIED",
+	"PAYMENT.CAPTURE.PENDING",
+	"PAYMENT.CAPTURE.REFUNDED",
+	"PAYMENT.CAPTURE.REVERSED",
+	"PAYMENT.ORDER.CANCELLED",
+	"PAYMENT.ORDER.CREATED",
+	"PAYMENT.SALE.COMPLETED",
+	"PAYMENT.SALE.DENIED",
+	"PAYMENT.SALE.PENDING",
+	"PAYMENT.SALE.REFUNDED",
+	"PAYMENT.SALE.REVERSED",
+
+	// Payouts
+	"PAYMENT.PAYOUTS-ITEM.BLOCKED",
+	"PAYMENT.PAYOUTS-ITEM.CANCELED",
+	"PAYMENT.PAYOUTS-ITEM.DENIED",
+	"PAYMENT.PAYOUTS-ITEM.FAILED",
+	"PAYMENT.PAYOUTS-ITEM.HELD",
+	"PAYMENT.PAYOUTS-ITEM.REFUNDED",
+	"PAYMENT.PAYOUTS-ITEM.RETURNED",
+	"PAYMENT.PAYOUTS-ITEM.SUCCEEDED",
+	"PAYMENT.PAYOUTS-ITEM.UNCLAIMED",
+	"PAYMENT.PAYOUTSBATCH.DENIED",
+	"PAYMENT.PAYOUTSBATCH.PROCESSING",
+	"PAYMENT.PAYOUTSBATCH.SUCCESS",
+	"PAYMENT.REFERENCED-PAYOUT-ITEM.COMPLETED",
+	"PAYMENT.REFERENCED-PAYOUT-ITEM.FAILED",
+
+	// Vault
+	"VAULT.PAYMENT-TOKEN.CREATED",
+	"VAULT.PAYMENT-TOKEN.DELETED",
+	"VAULT.PAYMENT-TOKEN.DELETION-INITIATED",
+}
+
+// isWebhookEventType Whether an event name is * or a documented event type, ignoring case
+func isWebhookEventType(name string) bool {
+	if name == webhookEventTypeAll {
+		return true
+	}
+	for _, eventType := range webhookEventTypes {
+		if strings.EqualFold(name, eventType) {
+			return true
+		}
+	}
+	return false
+}
+
+// validateWebhookEventType Validate an event type is * on its own or a name without wildcards. Whether the name is
+// an event type is checked by checkWebhookEventTypes at plan time, when PayPal can be asked about undocumented names
+func validateWebhookEventType(v interface{}, path cty.Path) diag.Diagnostics {
+	name := v.(string)
+	if name == "" || (name != webhookEventTypeAll && strings.Contains(name, webhookEventTypeAll)) {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       "Invalid webhook event type",
+			Detail:        fmt.Sprintf("%q is not a PayPal webhook event type. Use * on its own for all events", name),
+			AttributePath: path,
+		}}
+	}
+	return nil
+}
+
+// checkWebhookEventTypes Check every name is * or a webhook event type. Names missing from the documented list are
+// looked up in the event types PayPal lists, so event types added since the list was written can still be used
+func checkWebhookEventTypes(ctx context.Context, client PaypalClient, key string, names []string) error {
+	unknown := []string{}
+	for _, name := range names {
+		if !isWebhookEventType(name) {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+
+	listed := map[string]bool{}
+	if client != nil {
+		response, err := client.GetWebhookEventTypes(ctx)
+		if err != nil {
+			return fmt.Errorf("%s: error listing webhook event types: %w", key, err)
+		}
+		for _, eventType := range response.EventTypes {
+			listed[strings.ToUpper(eventType.Name)] = true
+		}
+	}
+
+	for _, name := range unknown {
+		if !listed[strings.ToUpper(name)] {
+			return fmt.Errorf("%s: %q is not a PayPal webhook event type. Use * for all events, or see the paypal_webhook_event_types data source for the available event types", key, name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package paypal
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/ollieparsley/terraform-provider-paypal/internal/fakepaypal"
+)
+
+func TestValidateWebhookEventType(t *testing.T) {
+	path := cty.GetAttrPath("event_types").IndexInt(0)
+	for _, name := range []string{"*", "PAYMENT.SALE.COMPLETED", "payment.sale.completed", "BILLING.SUBSCRIPTION.RE-ACTIVATED"} {
+		if diags := validateWebhookEventType(name, path); len(diags) > 0 {
+			t.Errorf("Expected %q to be valid. Got: %v", name, diags)
+		}
+	}
+	for _, name := range []string{"", "**", "PAYMENT.SALE.*"} {
+		if diags := validateWebhookEventType(name, path); !diags.HasError() {
+			t.Errorf("Expected %q to be invalid", name)
+		}
+	}
+}
+
+func TestCheckWebhookEventTypes(t *testing.T) {
+	server := fakepaypal.NewServer()
+	defer server.Close()
+	client := testProviderMeta(t, server).(*ProviderMeta).Client
+
+	// Documented names are known without asking PayPal
+	if err := checkWebhookEventTypes(context.Background(), nil, "event_types", []string{"*", "payment.sale.completed"}); err != nil {
+		t.Errorf("Expected documented event types to be valid. Got: %s", err)
+	}
+
+	// Names PayPal lists but the documented list does not are valid
+	if err := checkWebhookEventTypes(context.Background(), client, "event_types", []string{"payment.capture.declined"}); err != nil {
+		t.Errorf("Expected an event type PayPal lists to be valid. Got: %s", err)
+	}
+	if err := checkWebhookEventTypes(context.Background(), nil, "event_types", []string{"PAYMENT.CAPTURE.DECLINED"}); err == nil {
+		t.Errorf("Expected an undocumented event type to be invalid when PayPal cannot be asked")
+	}
+
+	if err := checkWebhookEventTypes(context.Background(), client, "event_types", []string{"PAYMENT.SALE.COMPLETED", "PAYMENT.SALE.COMPLETD"}); err == nil {
+		t.Errorf("Expected an error for a misspelt event type")
+	}
+}